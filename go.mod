@@ -10,23 +10,25 @@ require (
 	github.com/coreos/go-etcd v2.0.0+incompatible // indirect
 	github.com/go-openapi/swag v0.21.1 // indirect
 	github.com/go-playground/universal-translator v0.18.0 // indirect
-	github.com/go-playground/validator v9.31.0+incompatible // indirect
-	github.com/go-resty/resty/v2 v2.7.0 // indirect
-	github.com/gofiber/fiber/v2 v2.27.0 // indirect
-	github.com/google/uuid v1.3.0 // indirect
-	github.com/hashicorp/raft v1.3.6 // indirect
-	github.com/hashicorp/raft-boltdb/v2 v2.2.2 // indirect
+	github.com/go-playground/validator v9.31.0+incompatible
+	github.com/go-resty/resty/v2 v2.7.0
+	github.com/gofiber/fiber/v2 v2.27.0
+	github.com/google/uuid v1.3.0
+	github.com/hashicorp/raft v1.3.6
+	github.com/hashicorp/raft-boltdb/v2 v2.2.2
 	github.com/klauspost/compress v1.14.3 // indirect
 	github.com/leodido/go-urn v1.2.1 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
-	github.com/sirupsen/logrus v1.8.1 // indirect
-	github.com/spf13/viper v1.10.1 // indirect
+	github.com/sirupsen/logrus v1.8.1
+	github.com/spf13/viper v1.10.1
 	github.com/swaggo/swag v1.7.9 // indirect
 	github.com/ugorji/go/codec v0.0.0-20181204163529-d75b2dcb6bc8 // indirect
 	github.com/xordataexchange/crypt v0.0.3-0.20170626215501-b2862e3d0a77 // indirect
-	go.etcd.io/bbolt v1.3.6 // indirect
-	go.mongodb.org/mongo-driver v1.8.3 // indirect
+	go.etcd.io/bbolt v1.3.6
+	go.mongodb.org/mongo-driver v1.8.3
 	golang.org/x/net v0.0.0-20220127200216-cd36cc0744dd // indirect
 	golang.org/x/sys v0.0.0-20220209214540-3681064d5158 // indirect
 	golang.org/x/tools v0.1.9 // indirect
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 )
@@ -40,6 +40,8 @@ func RunApp() {
 
 	// init api server
 	apiSrv := app.NewServer()
+	// init grpc server
+	grpcSrv := app.NewGrpcServer()
 
 	// init storage
 	s, err := initStore()
@@ -72,6 +74,12 @@ func RunApp() {
 	ctrl.SystemRoutes(apiSrv.Srv)
 	// register routes
 	ctrl.PublicRoutes(apiSrv.Srv)
+	// register grpc services
+	ctrl.RegisterGrpc(grpcSrv.Srv)
+	// serve watches from the changes applied by replset
+	ctrl.RegisterWatch(rsStore.Broker())
+	// end the watch streams, otherwise the server waits for them on shutdown
+	apiSrv.OnShutdown(rsStore.Broker().Close)
 	// register reconciler
 	ctrl.RegisterReconciler(ctrlplane)
 
@@ -80,10 +88,16 @@ func RunApp() {
 		panic(fmt.Errorf("cannot run controlplane: %w", err))
 	}
 
+	// Run grpc server in background
+	if err = grpcSrv.Run(); err != nil {
+		panic(fmt.Errorf("cannot run grpc server: %w", err))
+	}
+
 	// Run server -> Start blocking from here
 	apiSrv.Run()
 
 	// cleanup
+	grpcSrv.Stop()
 	ctrlplane.Stop()
 	clus.Stop()
 	s.Close()
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/util"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// read from local store, may be stale on follower
+	ReadStale = "stale"
+	// read from leader's store, may be stale while leadership is changing
+	ReadLeader = "leader"
+	// read from leader's store after confirming leadership and applying every committed log
+	ReadLinearizable = "linearizable"
+
+	readBarrierTimeout = 5 * time.Second
+)
+
+// WithReadConsistency is a middleware which serves the read with the level given by "consistency" query,
+// read which can not be served by follower is forwarded to leader
+func (ctrl *Controller) WithReadConsistency(c *fiber.Ctx) error {
+	consistency := strings.ToLower(c.Query("consistency", ReadStale))
+
+	switch consistency {
+	case ReadStale:
+		return c.Next()
+	case ReadLeader, ReadLinearizable:
+	default:
+		return util.SendError(c, "invalid read consistency", exception.AppBadRequest(exception.Errorf("unknown consistency %v", consistency)))
+	}
+
+	if ctrl.c.AssertLeader() != nil {
+		return ctrl.forwardToLeader(c)
+	}
+
+	if consistency == ReadLinearizable {
+		if err := ctrl.c.ReadBarrier(readBarrierTimeout); err != nil {
+			return util.SendError(c, "unable to serve linearizable read", exception.AppServiceUnavailable(err))
+		}
+	}
+
+	return c.Next()
+}
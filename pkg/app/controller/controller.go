@@ -1,11 +1,14 @@
 package controller
 
 import (
+	"sync"
+
 	"github.com/barrydevp/transcoorditor/pkg/cluster"
 	"github.com/barrydevp/transcoorditor/pkg/common"
 	"github.com/barrydevp/transcoorditor/pkg/controlplane"
 	"github.com/barrydevp/transcoorditor/pkg/controlplane/reconciler"
 	"github.com/barrydevp/transcoorditor/pkg/service"
+	"github.com/barrydevp/transcoorditor/pkg/watch"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 )
@@ -15,16 +18,30 @@ var logger = common.Logger().WithFields(logrus.Fields{
 })
 
 type Controller struct {
-	c    *cluster.Cluster
-	srv  *service.Service
-	recl *reconciler.ScheduleReconciler
-	l    *logrus.Entry
+	c          *cluster.Cluster
+	srv        *service.Service
+	recl       *reconciler.ScheduleReconciler
+	retryRecl  *reconciler.ScheduleReconciler
+	lockRecl   *reconciler.ScheduleReconciler
+	leaseRecl  *reconciler.ScheduleReconciler
+	tccRecl    *reconciler.ScheduleReconciler
+	notifyRecl *reconciler.ScheduleReconciler
+	// sessions which are queued in notifyRecl
+	notifyQueued *notifyQueue
+	broker       *watch.Broker
+	forwardMode  string
+	l            *logrus.Entry
+
+	mutex    sync.Mutex
+	recovery *RecoveryReport
 }
 
 func NewController(c *cluster.Cluster, srv *service.Service) *Controller {
 	return &Controller{
-		c:   c,
-		srv: srv,
+		c:            c,
+		srv:          srv,
+		forwardMode:  getForwardMode(),
+		notifyQueued: newNotifyQueue(),
 		l: common.Logger().WithFields(logrus.Fields{
 			"pkg": "ctrl",
 		}),
@@ -46,6 +63,9 @@ func (ctrl *Controller) SystemRoutes(a *fiber.App) {
 	route.Get("/stats", ctrl.GetClusterStatsHttp)
 	route.Get("/leader", ctrl.GetClusterLeaderHttp)
 	route.Get("/nconf", ctrl.GetClusterCurrentHttp)
+
+	// report of the last in-processing sessions recovery
+	route.Get("/recovery", ctrl.GetRecoveryReportHttp)
 }
 
 func (ctrl *Controller) PublicRoutes(a *fiber.App) {
@@ -53,19 +73,31 @@ func (ctrl *Controller) PublicRoutes(a *fiber.App) {
 	route := a.Group("/api/v1")
 
 	// txn routes
-	route.Get("/sessions", ctrl.ListSessionHttp)
-	route.Get("/sessions/:sessionId", ctrl.GetSessionByIdHttp)
-	route.Put("/sessions/:sessionId", ctrl.PutSessionByIdHttp)
-	route.Post("/sessions", ctrl.StartSessionHttp)
-	route.Post("/sessions/:sessionId/join", ctrl.JoinSessionHttp)
-	route.Post("/sessions/:sessionId/partial-commit", ctrl.PartialCommitHttp)
-	route.Post("/sessions/:sessionId/commit", ctrl.CommitSessionHttp)
-	route.Post("/sessions/:sessionId/abort", ctrl.AbortSessionHttp)
-	route.Post("/sessions/:sessionId/forget", ctrl.ForgetSessionHttp)
-
-    // internal testing
-	route.Delete("/sessions/:sessionId", ctrl.DeleteSessionByIdHttp)
+	route.Get("/sessions", ctrl.WithReadConsistency, ctrl.ListSessionHttp)
+	route.Get("/sessions/:sessionId", ctrl.WithReadConsistency, ctrl.GetSessionByIdHttp)
+	// watches are served by every node from the changes it applies
+	route.Get("/sessions/:sessionId/watch", ctrl.WatchSessionHttp)
+	route.Get("/watch", ctrl.WatchHttp)
+	// mutating routes are handled by leader only
+	route.Put("/sessions/:sessionId", ctrl.ForwardToLeader, ctrl.PutSessionByIdHttp)
+	route.Post("/sessions", ctrl.ForwardToLeader, ctrl.StartSessionHttp)
+	route.Post("/sessions/:sessionId/join", ctrl.ForwardToLeader, ctrl.JoinSessionHttp)
+	route.Post("/sessions/:sessionId/partial-commit", ctrl.ForwardToLeader, ctrl.PartialCommitHttp)
+	route.Post("/sessions/:sessionId/participants/:participantId/heartbeat", ctrl.ForwardToLeader, ctrl.HeartbeatParticipantHttp)
+	route.Post("/sessions/:sessionId/commit", ctrl.ForwardToLeader, ctrl.CommitSessionHttp)
+	route.Post("/sessions/:sessionId/abort", ctrl.ForwardToLeader, ctrl.AbortSessionHttp)
+	route.Post("/sessions/:sessionId/forget", ctrl.ForwardToLeader, ctrl.ForgetSessionHttp)
+	route.Post("/sessions/:sessionId/keepalive", ctrl.ForwardToLeader, ctrl.KeepAliveSessionHttp)
 
+	// internal testing
+	route.Delete("/sessions/:sessionId", ctrl.ForwardToLeader, ctrl.DeleteSessionByIdHttp)
+
+	// lock routes
+	route.Get("/locks", ctrl.WithReadConsistency, ctrl.ListLockHttp)
+	route.Get("/locks/:key", ctrl.WithReadConsistency, ctrl.GetLockHttp)
+	route.Post("/locks/:key/acquire", ctrl.ForwardToLeader, ctrl.AcquireLockHttp)
+	route.Post("/locks/:key/extend", ctrl.ForwardToLeader, ctrl.ExtendLockHttp)
+	route.Post("/locks/:key/release", ctrl.ForwardToLeader, ctrl.ReleaseLockHttp)
 }
 
 func (ctrl *Controller) RegisterReconciler(c *controlplane.ControlPlane) {
@@ -73,4 +105,34 @@ func (ctrl *Controller) RegisterReconciler(c *controlplane.ControlPlane) {
 	recl := reconciler.NewScheduleReconciler(ctrl.InitTimeoutSessionQueueRecl, ctrl.HandleTimeoutSessionRecl)
 	ctrl.recl = recl
 	c.RegisterRecl(recl)
+
+	// retry failed sessions with their retry policy
+	retryRecl := reconciler.NewScheduleReconciler(ctrl.InitRetrySessionQueueRecl, ctrl.HandleRetrySessionRecl)
+	ctrl.retryRecl = retryRecl
+	c.RegisterRecl(retryRecl)
+
+	// delete expired locks
+	lockRecl := reconciler.NewScheduleReconciler(ctrl.InitExpiredLockQueueRecl, ctrl.HandleExpiredLockRecl)
+	ctrl.lockRecl = lockRecl
+	c.RegisterRecl(lockRecl)
+	ctrl.srv.Subscribe(ctrl.handleLockEvent)
+
+	// fail participants whose lease was expired
+	leaseRecl := reconciler.NewScheduleReconciler(ctrl.InitParticipantLeaseQueueRecl, ctrl.HandleParticipantLeaseRecl)
+	ctrl.leaseRecl = leaseRecl
+	c.RegisterRecl(leaseRecl)
+
+	// cancel reservations of TCC sessions before they expire
+	tccRecl := reconciler.NewScheduleReconciler(ctrl.InitReservationQueueRecl, ctrl.HandleReservationRecl)
+	ctrl.tccRecl = tccRecl
+	c.RegisterRecl(tccRecl)
+
+	// deliver session state to notification urls, state changes are only made by leader
+	notifyRecl := reconciler.NewScheduleReconciler(ctrl.InitNotificationQueueRecl, ctrl.HandleNotificationRecl)
+	ctrl.notifyRecl = notifyRecl
+	c.RegisterRecl(notifyRecl)
+	ctrl.srv.Subscribe(ctrl.handleSessionEvent)
+
+	// resume in-processing sessions of previous leader
+	c.RegisterRecl(reconciler.NewTaskReconciler(ctrl.RecoverSessionRecl))
 }
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/barrydevp/transcoorditor/pkg/cluster"
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/util"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/proxy"
+	"github.com/spf13/viper"
+)
+
+const (
+	// set on forwarded request for preventing forward loop when leadership is changing
+	forwardedHeader = "X-Transcoorditor-Forwarded-By"
+
+	forwardProxy    = "proxy"
+	forwardRedirect = "redirect"
+)
+
+var (
+	ErrNoLeader = exception.AppServiceUnavailable(exception.Errorf("cluster has no leader"))
+)
+
+func getForwardMode() string {
+	if strings.ToLower(viper.GetString("LEADER_FORWARD")) == forwardRedirect {
+		return forwardRedirect
+	}
+
+	return forwardProxy
+}
+
+// ForwardToLeader is a middleware which let leader handle the request,
+// follower proxies the request to leader or redirects client to it depend on LEADER_FORWARD
+func (ctrl *Controller) ForwardToLeader(c *fiber.Ctx) error {
+	if ctrl.c.AssertLeader() == nil {
+		return c.Next()
+	}
+
+	return ctrl.forwardToLeader(c)
+}
+
+func (ctrl *Controller) forwardToLeader(c *fiber.Ctx) error {
+	if by := c.Get(forwardedHeader); by != "" {
+		// the node forwarded this request think we are leader, leadership is changing
+		return util.SendError(c, "unable to forward request to leader", exception.AppServiceUnavailable(exception.Errorf("%w, forwarded by %v", cluster.ErrNotLeader, by)))
+	}
+
+	leader, err := ctrl.c.Leader()
+	if err != nil {
+		return util.SendError(c, "unable to get leader", err)
+	}
+	if leader == nil || leader.ApiAddr == "" {
+		return util.SendError(c, "unable to forward request to leader", ErrNoLeader)
+	}
+
+	leaderUrl := "http://" + leader.ApiAddr + c.OriginalURL()
+
+	if ctrl.forwardMode == forwardRedirect {
+		c.Location(leaderUrl)
+
+		return c.Status(fiber.StatusTemporaryRedirect).JSON(&fiber.Map{
+			"ok":     false,
+			"msg":    "redirect to leader",
+			"err":    cluster.ErrNotLeader.Error(),
+			"detail": leader,
+		})
+	}
+
+	ctrl.l.Debug("forward request to leader: ", leaderUrl)
+	c.Request().Header.Set(forwardedHeader, ctrl.c.SID())
+	if err := proxy.Do(c, leaderUrl); err != nil {
+		return util.SendError(c, "unable to forward request to leader", exception.AppServiceUnavailable(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/barrydevp/transcoorditor/pkg/cluster"
+	"github.com/barrydevp/transcoorditor/pkg/common"
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/rpc"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/watch"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// consistencyMetadataKey is the grpc counterpart of "consistency" query of http reads
+const consistencyMetadataKey = "consistency"
+
+// grpcController implements rpc.CoordinatorServer by the same services of public http routes
+type grpcController struct {
+	rpc.UnimplementedCoordinatorServer
+	*Controller
+}
+
+// grpcSystemController implements rpc.SystemServer by the same services of system http routes
+type grpcSystemController struct {
+	rpc.UnimplementedSystemServer
+	*Controller
+}
+
+// RegisterGrpc registers the coordinator service, the system service is registered only if GRPC_SYSTEM_ENABLED is set
+func (ctrl *Controller) RegisterGrpc(s *grpc.Server) {
+	rpc.RegisterCoordinatorServer(s, &grpcController{Controller: ctrl})
+
+	if viper.GetBool("GRPC_SYSTEM_ENABLED") {
+		rpc.RegisterSystemServer(s, &grpcSystemController{Controller: ctrl})
+	}
+}
+
+// assertLeader lets leader handle the write request, grpc is not proxied by follower,
+// it answers with the leader address in trailer the same as LEADER_FORWARD=redirect
+func (ctrl *grpcController) assertLeader(ctx context.Context) error {
+	if ctrl.c.AssertLeader() == nil {
+		return nil
+	}
+
+	leader, err := ctrl.c.Leader()
+	if err != nil {
+		return err
+	}
+	if leader == nil || leader.GrpcAddr == "" {
+		return ErrNoLeader
+	}
+
+	grpc.SetTrailer(ctx, metadata.Pairs(rpc.LeaderMetadataKey, leader.GrpcAddr))
+
+	return exception.AppServiceUnavailable(exception.Errorf("%w, leader is %v", cluster.ErrNotLeader, leader.GrpcAddr))
+}
+
+// withReadConsistency is the same as WithReadConsistency, the level is given by "consistency" metadata
+func (ctrl *grpcController) withReadConsistency(ctx context.Context) error {
+	consistency := ReadStale
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(consistencyMetadataKey); len(values) > 0 {
+			consistency = strings.ToLower(values[0])
+		}
+	}
+
+	switch consistency {
+	case ReadStale:
+		return nil
+	case ReadLeader, ReadLinearizable:
+	default:
+		return exception.AppBadRequest(exception.Errorf("unknown consistency %v", consistency))
+	}
+
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return err
+	}
+
+	if consistency == ReadLinearizable {
+		if err := ctrl.c.ReadBarrier(readBarrierTimeout); err != nil {
+			return exception.AppServiceUnavailable(err)
+		}
+	}
+
+	return nil
+}
+
+func (ctrl *grpcController) StartSession(ctx context.Context, req *rpc.SessionOptions) (*rpc.Session, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	opts, err := req.ToSchema()
+	if err != nil {
+		return nil, exception.AppBadRequest(err)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, exception.AppBadRequest(err)
+	}
+
+	session := schema.NewSession(opts)
+	if _, err := ctrl.srv.StartSession(session); err != nil {
+		return nil, err
+	}
+
+	ctrl.scheduleStartedSession(session)
+
+	return rpc.NewSession(session)
+}
+
+func (ctrl *grpcController) GetSession(ctx context.Context, req *rpc.SessionRequest) (*rpc.Session, error) {
+	if err := ctrl.withReadConsistency(ctx); err != nil {
+		return nil, err
+	}
+
+	session, err := ctrl.srv.GetSessionById(req.SessionId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewSession(session)
+}
+
+func (ctrl *grpcController) ListSession(ctx context.Context, req *emptypb.Empty) (*rpc.ListSessionResponse, error) {
+	if err := ctrl.withReadConsistency(ctx); err != nil {
+		return nil, err
+	}
+
+	sessions, err := ctrl.srv.ListSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewListSessionResponse(sessions)
+}
+
+func (ctrl *grpcController) JoinSession(ctx context.Context, req *rpc.JoinSessionRequest) (*rpc.Participant, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	body := req.ToSchema()
+	if err := body.Validate(); err != nil {
+		return nil, exception.AppBadRequest(err)
+	}
+
+	part := schema.NewParticipant()
+	part.SessionId = req.SessionId
+	part.ClientId = body.ClientId
+	part.RequestId = body.RequestId
+	part.LeaseTimeoutMs = body.LeaseTimeoutMs
+
+	part, err := ctrl.srv.JoinSession(req.SessionId, part)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.scheduleLeaseExpiry(part)
+
+	return rpc.NewParticipant(part)
+}
+
+func (ctrl *grpcController) PartialCommit(ctx context.Context, req *rpc.PartialCommitRequest) (*rpc.Participant, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	partCommit, err := req.ToSchema()
+	if err != nil {
+		return nil, exception.AppBadRequest(err)
+	}
+	if err := common.GetValidate().Struct(partCommit); err != nil {
+		return nil, exception.AppBadRequest(err)
+	}
+
+	part, err := ctrl.srv.PartialCommitSession(req.SessionId, partCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.scheduleReservationCancel(req.SessionId)
+
+	return rpc.NewParticipant(part)
+}
+
+func (ctrl *grpcController) HeartbeatParticipant(ctx context.Context, req *rpc.HeartbeatParticipantRequest) (*rpc.Participant, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	part, err := ctrl.srv.HeartbeatParticipant(req.SessionId, req.ParticipantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewParticipant(part)
+}
+
+func (ctrl *grpcController) KeepAliveSession(ctx context.Context, req *rpc.KeepAliveSessionRequest) (*rpc.Session, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	body := req.ToSchema()
+	if err := body.Validate(); err != nil {
+		return nil, exception.AppBadRequest(err)
+	}
+
+	session, err := ctrl.srv.KeepAliveSession(req.SessionId, body.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewSession(session)
+}
+
+func (ctrl *grpcController) CommitSession(ctx context.Context, req *rpc.SessionRequest) (*rpc.Session, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	session, err := ctrl.srv.CommitSession(req.SessionId)
+	ctrl.scheduleRetry(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewSession(session)
+}
+
+func (ctrl *grpcController) AbortSession(ctx context.Context, req *rpc.SessionRequest) (*rpc.Session, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	session, err := ctrl.srv.AbortSession(req.SessionId)
+	ctrl.scheduleRetry(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewSession(session)
+}
+
+func (ctrl *grpcController) ForgetSession(ctx context.Context, req *rpc.SessionRequest) (*rpc.Session, error) {
+	if err := ctrl.assertLeader(ctx); err != nil {
+		return nil, err
+	}
+
+	session, err := ctrl.srv.ForgetSession(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewSession(session)
+}
+
+// WatchSession is the grpc counterpart of WatchSessionHttp
+func (ctrl *grpcController) WatchSession(req *rpc.SessionRequest, stream rpc.Coordinator_WatchSessionServer) error {
+	if ctrl.broker == nil {
+		return exception.AppServiceUnavailable(ErrWatchUnavailable)
+	}
+
+	// watch before reading the session, so no transition is missed in between
+	w := ctrl.broker.Watch(req.SessionId)
+	defer w.Close()
+
+	if _, err := ctrl.srv.GetSessionById(req.SessionId, false); err != nil {
+		return err
+	}
+
+	return sendEvents(stream, w)
+}
+
+// Watch is the grpc counterpart of WatchHttp
+func (ctrl *grpcController) Watch(req *emptypb.Empty, stream rpc.Coordinator_WatchServer) error {
+	if ctrl.broker == nil {
+		return exception.AppServiceUnavailable(ErrWatchUnavailable)
+	}
+
+	w := ctrl.broker.Watch("")
+	defer w.Close()
+
+	return sendEvents(stream, w)
+}
+
+// sendEvents sends events of w until the client is gone or w is dropped for being slow
+func sendEvents(stream grpc.ServerStream, w *watch.Watcher) error {
+	for {
+		select {
+		case event, ok := <-w.C:
+			if !ok {
+				return nil
+			}
+
+			m, err := rpc.NewEvent(event)
+			if err != nil {
+				return err
+			}
+			if err := stream.SendMsg(m); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (ctrl *grpcController) Ping(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+func (ctrl *grpcSystemController) InitiateCluster(ctx context.Context, req *rpc.ClusterRsConf) (*rpc.ClusterRsConf, error) {
+	if err := ctrl.c.RsInitiate(req.ToCluster()); err != nil {
+		return nil, err
+	}
+
+	return ctrl.getClusterRsConf()
+}
+
+func (ctrl *grpcSystemController) JoinCluster(ctx context.Context, req *rpc.Node) (*rpc.ClusterRsConf, error) {
+	if err := ctrl.c.Join(req.ToCluster()); err != nil {
+		return nil, err
+	}
+
+	return ctrl.getClusterRsConf()
+}
+
+func (ctrl *grpcSystemController) LeftCluster(ctx context.Context, req *rpc.Node) (*rpc.ClusterRsConf, error) {
+	if err := ctrl.c.Left(req.ToCluster()); err != nil {
+		return nil, err
+	}
+
+	return ctrl.getClusterRsConf()
+}
+
+func (ctrl *grpcSystemController) getClusterRsConf() (*rpc.ClusterRsConf, error) {
+	conf, err := ctrl.c.GetConf()
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewClusterRsConf(conf), nil
+}
+
+func (ctrl *grpcSystemController) GetClusterRsConf(ctx context.Context, req *emptypb.Empty) (*rpc.ClusterRsConf, error) {
+	return ctrl.getClusterRsConf()
+}
+
+func (ctrl *grpcSystemController) GetClusterStats(ctx context.Context, req *emptypb.Empty) (*rpc.ClusterStatsResponse, error) {
+	stats, err := ctrl.c.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.ClusterStatsResponse{Stats: stats}, nil
+}
+
+func (ctrl *grpcSystemController) GetClusterLeader(ctx context.Context, req *emptypb.Empty) (*rpc.Node, error) {
+	leader, err := ctrl.c.Leader()
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewNode(leader), nil
+}
+
+func (ctrl *grpcSystemController) GetClusterCurrent(ctx context.Context, req *emptypb.Empty) (*rpc.Node, error) {
+	current, err := ctrl.c.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewNode(current), nil
+}
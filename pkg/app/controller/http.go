@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"strconv"
+
 	"github.com/barrydevp/transcoorditor/pkg/common"
 	"github.com/barrydevp/transcoorditor/pkg/exception"
 	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/service"
 	"github.com/barrydevp/transcoorditor/pkg/util"
 	"github.com/gofiber/fiber/v2"
 )
@@ -64,19 +67,30 @@ func (ctrl *Controller) StartSessionHttp(c *fiber.Ctx) error {
 	if err = c.BodyParser(sessionOpts); err != nil {
 		return util.SendError(c, "unable to parse start session request payload", err)
 	}
+	if err = sessionOpts.Validate(); err != nil {
+		return util.SendError(c, "invalid start session request payload", exception.AppBadRequest(err))
+	}
 
 	session := schema.NewSession(sessionOpts)
 	if _, err := ctrl.srv.StartSession(session); err != nil {
 		return util.SendError(c, "unable to start new session", err)
 	}
 
-	// schedule the cleanup of session when timeout
+	ctrl.scheduleStartedSession(session)
+
+	return util.SendOK(c, session)
+}
+
+// scheduleStartedSession schedules the cleanup of session when timeout and the reap of its locks
+func (ctrl *Controller) scheduleStartedSession(session *schema.Session) {
 	ctrl.recl.Schedule(&TimeoutSessionEntry{
 		TimedoutAt: session.TimedoutAt(),
 		SessionId:  session.Id,
 	})
 
-	return util.SendOK(c, session)
+	for _, key := range session.GetLockKeys() {
+		ctrl.scheduleLockReap(key, session.StartedAt.Add(service.SessionLockDuration))
+	}
 }
 
 func (ctrl *Controller) JoinSessionHttp(c *fiber.Ctx) error {
@@ -94,12 +108,15 @@ func (ctrl *Controller) JoinSessionHttp(c *fiber.Ctx) error {
 	part.SessionId = sessionId
 	part.ClientId = partJoinBody.ClientId
 	part.RequestId = partJoinBody.RequestId
+	part.LeaseTimeoutMs = partJoinBody.LeaseTimeoutMs
 
 	part, err := ctrl.srv.JoinSession(sessionId, part)
 	if err != nil {
 		return util.SendError(c, "unable to join session", err)
 	}
 
+	ctrl.scheduleLeaseExpiry(part)
+
 	return util.SendOK(c, part)
 }
 
@@ -119,6 +136,23 @@ func (ctrl *Controller) PartialCommitHttp(c *fiber.Ctx) error {
 		return util.SendError(c, "unable to partial commit session", err)
 	}
 
+	ctrl.scheduleReservationCancel(sessionId)
+
+	return util.SendOK(c, part)
+}
+
+func (ctrl *Controller) HeartbeatParticipantHttp(c *fiber.Ctx) error {
+	sessionId := c.Params("sessionId")
+	participantId, err := strconv.ParseInt(c.Params("participantId"), 10, 64)
+	if err != nil {
+		return util.SendError(c, "invalid participant id", exception.AppBadRequest(err))
+	}
+
+	part, err := ctrl.srv.HeartbeatParticipant(sessionId, participantId)
+	if err != nil {
+		return util.SendError(c, "unable to heartbeat participant", err)
+	}
+
 	return util.SendOK(c, part)
 }
 
@@ -126,6 +160,7 @@ func (ctrl *Controller) CommitSessionHttp(c *fiber.Ctx) error {
 	sessionId := c.Params("sessionId")
 
 	session, err := ctrl.srv.CommitSession(sessionId)
+	ctrl.scheduleRetry(session)
 	if err != nil {
 		return util.SendError(c, "unable to commit session", err)
 	}
@@ -137,6 +172,7 @@ func (ctrl *Controller) AbortSessionHttp(c *fiber.Ctx) error {
 	sessionId := c.Params("sessionId")
 
 	session, err := ctrl.srv.AbortSession(sessionId)
+	ctrl.scheduleRetry(session)
 	if err != nil {
 		return util.SendError(c, "unable to abort session", err)
 	}
@@ -144,6 +180,26 @@ func (ctrl *Controller) AbortSessionHttp(c *fiber.Ctx) error {
 	return util.SendOK(c, session)
 }
 
+func (ctrl *Controller) KeepAliveSessionHttp(c *fiber.Ctx) error {
+	sessionId := c.Params("sessionId")
+
+	body := &schema.SessionKeepAliveBody{}
+	if err := c.BodyParser(body); err != nil {
+		return util.SendError(c, "unable to parse keepalive session request payload", err)
+	}
+	if err := body.Validate(); err != nil {
+		return util.SendError(c, "invalid keepalive session request payload", exception.AppBadRequest(err))
+	}
+
+	// the timeout reconciler re-schedules the session by itself when it sees the extended deadline
+	session, err := ctrl.srv.KeepAliveSession(sessionId, body.Timeout)
+	if err != nil {
+		return util.SendError(c, "unable to keep session alive", err)
+	}
+
+	return util.SendOK(c, session)
+}
+
 func (ctrl *Controller) ForgetSessionHttp(c *fiber.Ctx) error {
 	sessionId := c.Params("sessionId")
 
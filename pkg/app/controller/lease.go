@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/controlplane/reconciler"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+type ParticipantLeaseEntry struct {
+	LeaseExpiredAt time.Time
+	SessionId      string
+	ParticipantId  int64
+}
+
+func (en *ParticipantLeaseEntry) ExpiredAt() *time.Time {
+	return &en.LeaseExpiredAt
+}
+
+// newParticipantLeaseEntry returns nil if the participant does not hold a lease
+func newParticipantLeaseEntry(part *schema.Participant) *ParticipantLeaseEntry {
+	if part == nil || !part.HasLease() {
+		return nil
+	}
+
+	return &ParticipantLeaseEntry{
+		LeaseExpiredAt: *part.LeaseExpiredAt,
+		SessionId:      part.SessionId,
+		ParticipantId:  part.Id,
+	}
+}
+
+// scheduleLeaseExpiry schedules the check of participant lease, it is re-scheduled if the lease was renewed
+func (ctrl *Controller) scheduleLeaseExpiry(part *schema.Participant) {
+	if entry := newParticipantLeaseEntry(part); entry != nil {
+		ctrl.leaseRecl.Schedule(entry)
+	}
+}
+
+func (ctrl *Controller) HandleParticipantLeaseRecl(entries []reconciler.ScheduleEntry) []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	for _, en := range entries {
+		if entry, ok := en.(*ParticipantLeaseEntry); ok {
+			part, session, err := ctrl.srv.ExpireParticipantLease(entry.SessionId, entry.ParticipantId)
+			if err != nil {
+				logger.Debug("expire participant lease failed: ", err)
+			}
+
+			// auto-aborted session
+			ctrl.scheduleRetry(session)
+
+			if newEntry := newParticipantLeaseEntry(part); newEntry != nil {
+				newEntries = append(newEntries, newEntry)
+			}
+		} else {
+			logger.Error("handleParticipantLease received malformed entry")
+		}
+	}
+
+	logger.Debug("handleParticipantLease done!")
+
+	return newEntries
+}
+
+func (ctrl *Controller) InitParticipantLeaseQueueRecl() []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	parts, err := ctrl.srv.GetAllLeasedParticipant()
+	if err != nil {
+		logger.Errorf("Cannot init participant lease queue reconiler")
+	}
+
+	for _, part := range parts {
+		if entry := newParticipantLeaseEntry(part); entry != nil {
+			newEntries = append(newEntries, entry)
+		}
+	}
+
+	return newEntries
+}
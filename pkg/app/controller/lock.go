@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/url"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/util"
+	"github.com/gofiber/fiber/v2"
+)
+
+// lock key may contain escaped characters, eg: "/"
+func getLockKey(c *fiber.Ctx) (string, error) {
+	key, err := url.PathUnescape(c.Params("key"))
+	if err != nil {
+		return "", exception.AppBadRequest(err)
+	}
+
+	return key, nil
+}
+
+func (ctrl *Controller) ListLockHttp(c *fiber.Ctx) error {
+	locks, err := ctrl.srv.ListLock()
+	if err != nil {
+		return util.SendError(c, "unable to list locks", err)
+	}
+
+	return util.SendOK(c, locks)
+}
+
+func (ctrl *Controller) GetLockHttp(c *fiber.Ctx) error {
+	key, err := getLockKey(c)
+	if err != nil {
+		return util.SendError(c, "invalid lock key", err)
+	}
+
+	lockEnt, err := ctrl.srv.GetLock(key)
+	if err != nil {
+		return util.SendError(c, "unable to get lock", err)
+	}
+
+	return util.SendOK(c, lockEnt)
+}
+
+func (ctrl *Controller) AcquireLockHttp(c *fiber.Ctx) error {
+	key, err := getLockKey(c)
+	if err != nil {
+		return util.SendError(c, "invalid lock key", err)
+	}
+
+	body := &schema.LockAcquireBody{}
+	if err := c.BodyParser(body); err != nil {
+		return util.SendError(c, "unable to parse acquire lock request payload", err)
+	}
+	if err := body.Validate(); err != nil {
+		return util.SendError(c, "invalid acquire lock request payload", exception.AppBadRequest(err))
+	}
+
+	lockEnt, err := ctrl.srv.AcquireLockWait(key, body.Owner, body.GetMode(), body.GetTtl(), body.GetWait())
+	if err != nil {
+		return util.SendError(c, "unable to acquire lock", err)
+	}
+
+	ctrl.scheduleLockReap(lockEnt.Key, *lockEnt.ExpiredAt)
+
+	return util.SendOK(c, lockEnt)
+}
+
+func (ctrl *Controller) ExtendLockHttp(c *fiber.Ctx) error {
+	key, err := getLockKey(c)
+	if err != nil {
+		return util.SendError(c, "invalid lock key", err)
+	}
+
+	body := &schema.LockAcquireBody{}
+	if err := c.BodyParser(body); err != nil {
+		return util.SendError(c, "unable to parse extend lock request payload", err)
+	}
+	if err := body.Validate(); err != nil {
+		return util.SendError(c, "invalid extend lock request payload", exception.AppBadRequest(err))
+	}
+
+	lockEnt, err := ctrl.srv.ExtendLock(key, body.Owner, body.GetTtl())
+	if err != nil {
+		return util.SendError(c, "unable to extend lock", err)
+	}
+
+	return util.SendOK(c, lockEnt)
+}
+
+func (ctrl *Controller) ReleaseLockHttp(c *fiber.Ctx) error {
+	key, err := getLockKey(c)
+	if err != nil {
+		return util.SendError(c, "invalid lock key", err)
+	}
+
+	body := &schema.LockReleaseBody{}
+	if err := c.BodyParser(body); err != nil {
+		return util.SendError(c, "unable to parse release lock request payload", err)
+	}
+	if err := body.Validate(); err != nil {
+		return util.SendError(c, "invalid release lock request payload", exception.AppBadRequest(err))
+	}
+
+	// releasing a lock which is not held by owner is a no-op
+	if err := ctrl.srv.ReleaseLock(key, body.Owner); err != nil {
+		return util.SendError(c, "unable to release lock", err)
+	}
+
+	return util.SendOK(c, nil)
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/controlplane/reconciler"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+type ExpiredLockEntry struct {
+	LockExpiredAt time.Time
+	Key           string
+}
+
+func (en *ExpiredLockEntry) ExpiredAt() *time.Time {
+	return &en.LockExpiredAt
+}
+
+// scheduleLockReap schedules the lock to be reaped at expiredAt, it is re-scheduled if the lock was extended
+func (ctrl *Controller) scheduleLockReap(key string, expiredAt time.Time) {
+	ctrl.lockRecl.Schedule(&ExpiredLockEntry{
+		LockExpiredAt: expiredAt,
+		Key:           key,
+	})
+}
+
+// handleLockEvent publishes the expiry of locks of unfinished sessions to watchers, it is logged by ReapLock.
+// Locks are reaped by leader, so only watchers of leader receive them
+func (ctrl *Controller) handleLockEvent(event *schema.Event) {
+	if event.Type != schema.EventLockExpired {
+		return
+	}
+
+	if ctrl.broker != nil {
+		ctrl.broker.Publish(event)
+	}
+}
+
+func (ctrl *Controller) HandleExpiredLockRecl(entries []reconciler.ScheduleEntry) []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	for _, en := range entries {
+		if entry, ok := en.(*ExpiredLockEntry); ok {
+			expiredAt, err := ctrl.srv.ReapLock(entry.Key)
+			if err != nil {
+				logger.Debug("reap lock failed: ", err)
+
+				// try again later
+				retryAt := time.Now().Add(time.Minute)
+				expiredAt = &retryAt
+			}
+
+			if expiredAt != nil {
+				newEntries = append(newEntries, &ExpiredLockEntry{
+					LockExpiredAt: *expiredAt,
+					Key:           entry.Key,
+				})
+			}
+		} else {
+			logger.Error("handleExpiredLock received malformed entry")
+		}
+	}
+
+	logger.Debug("handleExpiredLock done!")
+
+	return newEntries
+}
+
+func (ctrl *Controller) InitExpiredLockQueueRecl() []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	locks, err := ctrl.srv.ListLock()
+	if err != nil {
+		logger.Errorf("Cannot init expired lock queue reconiler")
+	}
+
+	for _, lockEnt := range locks {
+		newEntries = append(newEntries, &ExpiredLockEntry{
+			LockExpiredAt: *lockEnt.ExpiredAt,
+			Key:           lockEnt.Key,
+		})
+	}
+
+	return newEntries
+}
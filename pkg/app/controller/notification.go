@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/controlplane/reconciler"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+type NotificationEntry struct {
+	NotifyAt  time.Time
+	SessionId string
+}
+
+func (en *NotificationEntry) ExpiredAt() *time.Time {
+	return &en.NotifyAt
+}
+
+// newNotificationEntry returns nil if the session has no pending notification
+func newNotificationEntry(sessionId string, notifyAt *time.Time) *NotificationEntry {
+	if notifyAt == nil {
+		return nil
+	}
+
+	return &NotificationEntry{
+		NotifyAt:  *notifyAt,
+		SessionId: sessionId,
+	}
+}
+
+// notifyQueue keeps the earliest queued entry of each session, so a session is notified once at a time
+type notifyQueue struct {
+	mutex sync.Mutex
+	at    map[string]time.Time
+}
+
+func newNotifyQueue() *notifyQueue {
+	return &notifyQueue{
+		at: make(map[string]time.Time),
+	}
+}
+
+// add returns false if the session has been queued at or before the entry
+func (q *notifyQueue) add(entry *NotificationEntry) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if at, ok := q.at[entry.SessionId]; ok && !entry.NotifyAt.Before(at) {
+		return false
+	}
+	q.at[entry.SessionId] = entry.NotifyAt
+
+	return true
+}
+
+// take dequeues the session of entry, it returns false if the entry was superseded by an earlier one
+func (q *notifyQueue) take(entry *NotificationEntry) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if at, ok := q.at[entry.SessionId]; !ok || !at.Equal(entry.NotifyAt) {
+		return false
+	}
+	delete(q.at, entry.SessionId)
+
+	return true
+}
+
+func (q *notifyQueue) reset() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.at = make(map[string]time.Time)
+}
+
+// handleSessionEvent schedules the notification of session state as soon as it was changed
+func (ctrl *Controller) handleSessionEvent(event *schema.Event) {
+	if event.Type != schema.EventSessionStateChanged {
+		return
+	}
+
+	session, ok := event.Data.(*schema.Session)
+	if !ok || !session.IsNotifying() {
+		return
+	}
+
+	now := time.Now()
+	if entry := newNotificationEntry(session.Id, &now); ctrl.notifyQueued.add(entry) {
+		ctrl.notifyRecl.Schedule(entry)
+	}
+}
+
+func (ctrl *Controller) HandleNotificationRecl(entries []reconciler.ScheduleEntry) []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	for _, en := range entries {
+		if entry, ok := en.(*NotificationEntry); ok {
+			if !ctrl.notifyQueued.take(entry) {
+				// the session has been notified by an earlier entry
+				continue
+			}
+
+			notifyAt, err := ctrl.srv.NotifySession(entry.SessionId)
+			if err != nil {
+				logger.Debug("notify session failed: ", err)
+			}
+
+			if newEntry := newNotificationEntry(entry.SessionId, notifyAt); newEntry != nil && ctrl.notifyQueued.add(newEntry) {
+				newEntries = append(newEntries, newEntry)
+			}
+		} else {
+			logger.Error("handleNotification received malformed entry")
+		}
+	}
+
+	logger.Debug("handleNotification done!")
+
+	return newEntries
+}
+
+func (ctrl *Controller) InitNotificationQueueRecl() []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	// queue of the previous leadership was dropped
+	ctrl.notifyQueued.reset()
+
+	sessions, err := ctrl.srv.GetAllNotifyingSession()
+	if err != nil {
+		logger.Errorf("Cannot init notification queue reconiler")
+	}
+
+	for _, session := range sessions {
+		if entry := newNotificationEntry(session.Id, session.NextNotifyAt()); entry != nil && ctrl.notifyQueued.add(entry) {
+			newEntries = append(newEntries, entry)
+		}
+	}
+
+	return newEntries
+}
@@ -18,28 +18,12 @@ func (en *TimeoutSessionEntry) ExpiredAt() *time.Time {
 	return &en.TimedoutAt
 }
 
-func shouldRetryAfter2Mins(session *schema.Session, err error) bool {
-	if errors.Is(err, service.ErrSessionNotFound) || session == nil {
-		// dont retry not found entry
-		return false
-	}
-
-	if errors.Is(err, service.ErrSessionMaximumRetry) || session.IsMaximumRetry() {
-		// dont retry maximum retry entry
-		return false
-	}
-
-	return true
-}
-
 func isSessionNotExpiredYet(session *schema.Session, err error) bool {
 	return errors.Is(err, service.ErrSessionNotExpiredYet) && session != nil
 }
 
 func (ctrl *Controller) HandleTimeoutSessionRecl(entries []reconciler.ScheduleEntry) []reconciler.ScheduleEntry {
 	var newEntries []reconciler.ScheduleEntry
-	// use same Now() for all new Entry which reduce the number of loop in schedule
-	now := time.Now()
 
 	for _, en := range entries {
 		if entry, ok := en.(*TimeoutSessionEntry); ok {
@@ -51,14 +35,10 @@ func (ctrl *Controller) HandleTimeoutSessionRecl(entries []reconciler.ScheduleEn
 						SessionId:  entry.SessionId,
 					})
 
-				} else if shouldRetryAfter2Mins(session, err) {
-					logger.Debug("terminate failed, retry after 2 mins")
-					// retry after 2 mins
+				} else if !errors.Is(err, service.ErrSessionMaximumRetry) {
+					logger.Debug("terminate failed, retry by session's retry policy")
 
-					newEntries = append(newEntries, &TimeoutSessionEntry{
-						TimedoutAt: now.Add(2 * time.Minute),
-						SessionId:  entry.SessionId,
-					})
+					ctrl.scheduleRetry(session)
 				}
 			}
 		} else {
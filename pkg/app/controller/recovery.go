@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/util"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RecoveredSession struct {
+	SessionId string              `json:"sessionId"`
+	FromState schema.SessionState `json:"fromState"`
+	State     schema.SessionState `json:"state,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+type RecoveryReport struct {
+	StartedAt *time.Time          `json:"startedAt"`
+	EndAt     *time.Time          `json:"endAt,omitempty"`
+	Sessions  []*RecoveredSession `json:"sessions"`
+	Error     string              `json:"error,omitempty"`
+}
+
+func (ctrl *Controller) setRecoveryReport(report *RecoveryReport) {
+	ctrl.mutex.Lock()
+	defer ctrl.mutex.Unlock()
+
+	ctrl.recovery = report
+}
+
+// RecoverSessionRecl resumes sessions which were in middle of Committing/Aborting/Terminating
+// when the previous leader crashed, it runs every time this node becomes leader
+func (ctrl *Controller) RecoverSessionRecl(stopCh <-chan struct{}) {
+	now := time.Now()
+	report := &RecoveryReport{
+		StartedAt: &now,
+	}
+	defer ctrl.setRecoveryReport(report)
+
+	sessions, err := ctrl.srv.GetAllInProcessingSession()
+	if err != nil {
+		logger.Error("Cannot get in-processing sessions for recovery: ", err)
+		report.Error = err.Error()
+		return
+	}
+
+	for _, session := range sessions {
+		select {
+		case <-stopCh:
+			// lost leadership, new leader will take over
+			logger.Warn("recovery was stopped, remaining sessions: ", len(sessions)-len(report.Sessions))
+			return
+		default:
+		}
+
+		recovered := &RecoveredSession{
+			SessionId: session.Id,
+			FromState: session.State,
+		}
+		report.Sessions = append(report.Sessions, recovered)
+
+		result, err := ctrl.srv.RecoverySession(session.Id)
+		if err != nil {
+			recovered.Error = err.Error()
+		}
+		if result != nil {
+			recovered.State = result.State
+
+			// failed session is retried by timeout reconciler
+			if result.CheckSessionFailed() != nil && result.StartedAt != nil {
+				ctrl.recl.Schedule(&TimeoutSessionEntry{
+					TimedoutAt: result.TimedoutAt(),
+					SessionId:  result.Id,
+				})
+			}
+			ctrl.scheduleRetry(result)
+		}
+
+		logger.Infof("recovered session %v: %v -> %v %v", recovered.SessionId, recovered.FromState, recovered.State, recovered.Error)
+	}
+
+	endAt := time.Now()
+	report.EndAt = &endAt
+
+	logger.Infof("recovery done, %v sessions recovered", len(report.Sessions))
+}
+
+func (ctrl *Controller) GetRecoveryReportHttp(c *fiber.Ctx) error {
+	ctrl.mutex.Lock()
+	defer ctrl.mutex.Unlock()
+
+	return util.SendOK(c, ctrl.recovery)
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/controlplane/reconciler"
+	"github.com/barrydevp/transcoorditor/pkg/service"
+)
+
+type ReservationEntry struct {
+	CancelAt  time.Time
+	SessionId string
+}
+
+func (en *ReservationEntry) ExpiredAt() *time.Time {
+	return &en.CancelAt
+}
+
+// newReservationEntry returns nil if the session holds no reservation
+func newReservationEntry(sessionId string, cancelAt *time.Time) *ReservationEntry {
+	if cancelAt == nil {
+		return nil
+	}
+
+	return &ReservationEntry{
+		CancelAt:  *cancelAt,
+		SessionId: sessionId,
+	}
+}
+
+// scheduleReservationCancel schedules the cancel of TCC session at its earliest reservation
+func (ctrl *Controller) scheduleReservationCancel(sessionId string) {
+	session, err := ctrl.srv.GetSessionById(sessionId, true)
+	if err != nil {
+		logger.Debug("schedule reservation cancel failed: ", err)
+		return
+	}
+
+	if entry := newReservationEntry(session.Id, service.ReservationCancelAt(session)); entry != nil {
+		ctrl.tccRecl.Schedule(entry)
+	}
+}
+
+func (ctrl *Controller) HandleReservationRecl(entries []reconciler.ScheduleEntry) []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	for _, en := range entries {
+		if entry, ok := en.(*ReservationEntry); ok {
+			cancelAt, session, err := ctrl.srv.CancelExpiringReservations(entry.SessionId)
+			if err != nil {
+				logger.Debug("cancel expiring reservations failed: ", err)
+			}
+
+			// failed cancel is retried with session retry policy
+			ctrl.scheduleRetry(session)
+
+			if newEntry := newReservationEntry(entry.SessionId, cancelAt); newEntry != nil {
+				newEntries = append(newEntries, newEntry)
+			}
+		} else {
+			logger.Error("handleReservation received malformed entry")
+		}
+	}
+
+	logger.Debug("handleReservation done!")
+
+	return newEntries
+}
+
+func (ctrl *Controller) InitReservationQueueRecl() []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	sessions, err := ctrl.srv.GetAllUnFinishedSession()
+	if err != nil {
+		logger.Errorf("Cannot init reservation queue reconiler")
+	}
+
+	for _, session := range sessions {
+		if !session.IsTCC() {
+			continue
+		}
+
+		populated, err := ctrl.srv.GetSessionById(session.Id, true)
+		if err != nil {
+			logger.Errorf("Cannot get participants of session %v: %v", session.Id, err)
+			continue
+		}
+
+		if entry := newReservationEntry(session.Id, service.ReservationCancelAt(populated)); entry != nil {
+			newEntries = append(newEntries, entry)
+		}
+	}
+
+	return newEntries
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"errors"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/controlplane/reconciler"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/service"
+)
+
+type RetrySessionEntry struct {
+	RetryAt   time.Time
+	SessionId string
+}
+
+func (en *RetrySessionEntry) ExpiredAt() *time.Time {
+	return &en.RetryAt
+}
+
+// newRetrySessionEntry returns nil if the session should not be retried
+func newRetrySessionEntry(session *schema.Session) *RetrySessionEntry {
+	if session == nil || session.CheckSessionFailed() == nil {
+		return nil
+	}
+
+	if session.NextRetryAt == nil || session.IsMaximumRetry() {
+		return nil
+	}
+
+	return &RetrySessionEntry{
+		RetryAt:   *session.NextRetryAt,
+		SessionId: session.Id,
+	}
+}
+
+func (ctrl *Controller) scheduleRetry(session *schema.Session) {
+	if entry := newRetrySessionEntry(session); entry != nil {
+		ctrl.retryRecl.Schedule(entry)
+	}
+}
+
+func (ctrl *Controller) HandleRetrySessionRecl(entries []reconciler.ScheduleEntry) []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	for _, en := range entries {
+		if entry, ok := en.(*RetrySessionEntry); ok {
+			session, err := ctrl.srv.RetrySession(entry.SessionId)
+			if err != nil {
+				if errors.Is(err, service.ErrSessionNotRetryYet) {
+					// session was retried by someone else, who has scheduled the next retry
+					continue
+				}
+
+				logger.Debug("retry session failed: ", err)
+			}
+
+			if newEntry := newRetrySessionEntry(session); newEntry != nil {
+				newEntries = append(newEntries, newEntry)
+			}
+		} else {
+			logger.Error("handleRetrySession received malformed entry")
+		}
+	}
+
+	logger.Debug("handleRetrySession done!")
+
+	return newEntries
+}
+
+func (ctrl *Controller) InitRetrySessionQueueRecl() []reconciler.ScheduleEntry {
+	var newEntries []reconciler.ScheduleEntry
+
+	sessions, err := ctrl.srv.GetAllUnFinishedSession()
+	if err != nil {
+		logger.Errorf("Cannot init retry session queue reconiler")
+	}
+
+	for _, session := range sessions {
+		if entry := newRetrySessionEntry(session); entry != nil {
+			newEntries = append(newEntries, entry)
+		}
+	}
+
+	return newEntries
+}
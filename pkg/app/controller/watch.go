@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/util"
+	"github.com/barrydevp/transcoorditor/pkg/watch"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// comment line is sent when there is no event, so the broken connection is detected
+	watchPingInterval = 15 * time.Second
+)
+
+var (
+	ErrWatchUnavailable = errors.New("watch is not available on this node")
+)
+
+// RegisterWatch serves watches from the changes published by broker
+func (ctrl *Controller) RegisterWatch(broker *watch.Broker) {
+	ctrl.broker = broker
+}
+
+// WatchHttp streams state transitions of all sessions and participants as server-sent events,
+// expiry of session locks is streamed too, see handleLockEvent
+func (ctrl *Controller) WatchHttp(c *fiber.Ctx) error {
+	if ctrl.broker == nil {
+		return util.SendError(c, "unable to watch", exception.AppServiceUnavailable(ErrWatchUnavailable))
+	}
+
+	return ctrl.streamEvents(c, ctrl.broker.Watch(""))
+}
+
+// WatchSessionHttp streams state transitions of the session and its participants as server-sent events,
+// expiry of the session locks is streamed too, see handleLockEvent
+func (ctrl *Controller) WatchSessionHttp(c *fiber.Ctx) error {
+	sessionId := c.Params("sessionId")
+
+	if ctrl.broker == nil {
+		return util.SendError(c, "unable to watch session", exception.AppServiceUnavailable(ErrWatchUnavailable))
+	}
+
+	// watch before reading the session, so no transition is missed in between
+	w := ctrl.broker.Watch(sessionId)
+
+	if _, err := ctrl.srv.GetSessionById(sessionId, false); err != nil {
+		w.Close()
+		return util.SendError(c, "unable to watch session", err)
+	}
+
+	return ctrl.streamEvents(c, w)
+}
+
+// streamEvents writes events of w until the client is gone or w is dropped for being slow,
+// the client should re-read the state and watch again after the stream ended
+func (ctrl *Controller) streamEvents(c *fiber.Ctx, w *watch.Watcher) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(bw *bufio.Writer) {
+		defer w.Close()
+
+		ticker := time.NewTicker(watchPingInterval)
+		defer ticker.Stop()
+
+		// flush headers, so the client knows the watch has started
+		fmt.Fprint(bw, ": watching\n\n")
+		if err := bw.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-w.C:
+				if !ok {
+					return
+				}
+
+				if err := writeEvent(bw, event); err != nil {
+					ctrl.l.Debug("write watch event failed: ", err)
+					return
+				}
+			case <-ticker.C:
+				fmt.Fprint(bw, ": ping\n\n")
+			}
+
+			// the client is gone
+			if err := bw.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+func writeEvent(bw *bufio.Writer, event *schema.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(bw, "id: %v\nevent: %v\ndata: %s\n\n", event.Id, event.Type, data)
+
+	return err
+}
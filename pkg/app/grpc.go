@@ -0,0 +1,63 @@
+package app
+
+import (
+	"net"
+
+	"github.com/barrydevp/transcoorditor/pkg/common"
+	"github.com/barrydevp/transcoorditor/pkg/rpc"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+type GrpcServer struct {
+	Srv *grpc.Server
+	l   *logrus.Entry
+}
+
+func getGrpcServerUrl() string {
+	portNumber := viper.GetString("GRPC_PORT")
+
+	if portNumber == "" {
+		portNumber = "9000"
+	}
+
+	return ":" + portNumber
+}
+
+func NewGrpcServer() *GrpcServer {
+	srv := grpc.NewServer(
+		// errors of handlers are exception.AppError, they are converted to grpc status
+		grpc.UnaryInterceptor(rpc.StatusInterceptor),
+		grpc.StreamInterceptor(rpc.StreamStatusInterceptor),
+	)
+
+	return &GrpcServer{
+		Srv: srv,
+		l: common.Logger().WithFields(logrus.Fields{
+			"pkg": "api/grpc",
+		}),
+	}
+}
+
+// Run serves grpc api in background, it is stopped by Stop
+func (s *GrpcServer) Run() error {
+	lis, err := net.Listen("tcp", getGrpcServerUrl())
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.Srv.Serve(lis); err != nil {
+			s.l.Errorf("Oops... gRPC Server is not running! Reason: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *GrpcServer) Stop() {
+	s.Srv.GracefulStop()
+
+	s.l.Info("gRPC Server is shutdown.")
+}
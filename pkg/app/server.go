@@ -17,6 +17,13 @@ type ApiServer struct {
 	Srv     *fiber.App
 	CloseCh chan struct{}
 	l       *logrus.Entry
+	// called before shutdown, so long-lived requests can be ended
+	shutdownFns []func()
+}
+
+// OnShutdown registers fn to be called before the server is shutdown
+func (s *ApiServer) OnShutdown(fn func()) {
+	s.shutdownFns = append(s.shutdownFns, fn)
 }
 
 func (s *ApiServer) WithGracefulShutdown() {
@@ -28,9 +35,12 @@ func (s *ApiServer) WithGracefulShutdown() {
 		<-sigint
 
 		// Received an interrupt signal, shutdown.
+		for _, fn := range s.shutdownFns {
+			fn()
+		}
 		if err := s.Srv.Shutdown(); err != nil {
 			// Error from closing listeners, or context timeout:
-			s.l.Error("Oops... Cannot shutdown Server! Reason: %v. Force shutdown!\n", err)
+			s.l.Errorf("Oops... Cannot shutdown Server! Reason: %v. Force shutdown!", err)
 		}
 
 		close(s.CloseCh)
@@ -96,7 +106,7 @@ func (s *ApiServer) Run() {
 
 	// Run server.
 	if err := s.Srv.Listen(getServerUrl()); err != nil {
-		s.l.Error("Oops... Server is not running! Reason: %v", err)
+		s.l.Errorf("Oops... Server is not running! Reason: %v", err)
 	}
 
 	if s.CloseCh != nil {
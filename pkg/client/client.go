@@ -0,0 +1,259 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	defaultRetryWait  = 100 * time.Millisecond
+	maxRetryWait      = 2 * time.Second
+	// abort of WithSession is not bound to the context of caller, which may have been done
+	abortTimeout = 5 * time.Second
+)
+
+var (
+	ErrNoAddr = errors.New("transcoorditor: no address of the cluster")
+)
+
+type Config struct {
+	// http api addresses of cluster nodes, eg: localhost:8000 or http://localhost:8000
+	Addrs []string
+	// timeout of each request, 0 is the default
+	Timeout time.Duration
+	// number of retries of a failed call, 0 is the default and negative disables retry
+	MaxRetries int
+	// wait before the first retry, it is doubled on each next retry
+	RetryWait time.Duration
+	// read consistency of sessions and locks, see controller.WithReadConsistency. Default is stale
+	Consistency string
+}
+
+// Client calls the http api of the cluster leader, which is discovered through /api/sys/leader
+type Client struct {
+	r     *resty.Client
+	cfg   Config
+	addrs []string
+
+	mutex  sync.Mutex
+	leader string
+}
+
+func New(cfg Config) (*Client, error) {
+	if len(cfg.Addrs) == 0 {
+		return nil, ErrNoAddr
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.RetryWait <= 0 {
+		cfg.RetryWait = defaultRetryWait
+	}
+
+	addrs := make([]string, 0, len(cfg.Addrs))
+	for _, addr := range cfg.Addrs {
+		addrs = append(addrs, baseUrl(addr))
+	}
+
+	r := resty.New().
+		SetTimeout(cfg.Timeout).
+		// redirect to leader is handled by the client
+		SetRedirectPolicy(resty.RedirectPolicyFunc(func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}))
+
+	return &Client{
+		r:     r,
+		cfg:   cfg,
+		addrs: addrs,
+	}, nil
+}
+
+func baseUrl(addr string) string {
+	addr = strings.TrimRight(addr, "/")
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+
+	return addr
+}
+
+// call describes a request of the api
+type call struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+	// the call can be safely repeated, eg: read, commit of committed session
+	idempotent bool
+}
+
+// getLeader returns the leader address, it is discovered if unknown.
+// The first reachable node is used when no leader is known, followers forward requests to leader
+func (c *Client) getLeader(ctx context.Context) string {
+	c.mutex.Lock()
+	leader := c.leader
+	c.mutex.Unlock()
+
+	if leader != "" {
+		return leader
+	}
+
+	leader = c.discoverLeader(ctx)
+
+	c.mutex.Lock()
+	c.leader = leader
+	c.mutex.Unlock()
+
+	return leader
+}
+
+func (c *Client) discoverLeader(ctx context.Context) string {
+	for _, addr := range c.addrs {
+		node := &Node{}
+		if err := c.execute(ctx, addr, &call{method: http.MethodGet, path: "/api/sys/leader"}, node); err != nil {
+			continue
+		}
+
+		if node.ApiAddr != "" {
+			return baseUrl(node.ApiAddr)
+		}
+
+		return addr
+	}
+
+	return c.addrs[0]
+}
+
+func (c *Client) setLeader(leader string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.leader = leader
+}
+
+// redirectError is answered by follower when LEADER_FORWARD=redirect
+type redirectError struct {
+	leader string
+}
+
+func (e *redirectError) Error() string {
+	return fmt.Sprintf("transcoorditor: redirect to leader %v", e.leader)
+}
+
+// execute sends the call to addr and decodes data of response into out
+func (c *Client) execute(ctx context.Context, addr string, ca *call, out interface{}) error {
+	req := c.r.R().SetContext(ctx)
+	if ca.query != nil {
+		req.SetQueryParamsFromValues(ca.query)
+	}
+	if ca.body != nil {
+		req.SetHeader("Content-Type", "application/json").SetBody(ca.body)
+	}
+
+	resp, err := req.Execute(ca.method, addr+ca.path)
+	if err != nil {
+		return err
+	}
+
+	env := &envelope{}
+	if err := json.Unmarshal(resp.Body(), env); err != nil {
+		return fmt.Errorf("transcoorditor: unable to decode response (%v): %w", resp.StatusCode(), err)
+	}
+
+	if resp.StatusCode() == http.StatusTemporaryRedirect {
+		node := &Node{}
+		if err := json.Unmarshal(env.Detail, node); err == nil && node.ApiAddr != "" {
+			return &redirectError{leader: baseUrl(node.ApiAddr)}
+		}
+	}
+
+	if !env.Ok {
+		return newError(resp.StatusCode(), env)
+	}
+
+	if out != nil && len(env.Data) > 0 {
+		return json.Unmarshal(env.Data, out)
+	}
+
+	return nil
+}
+
+// do executes the call on leader, it is retried on redirect and on failures which are safe to retry
+func (c *Client) do(ctx context.Context, ca *call, out interface{}) error {
+	wait := c.cfg.RetryWait
+
+	for attempt := 0; ; attempt++ {
+		err := c.execute(ctx, c.getLeader(ctx), ca, out)
+		if err == nil {
+			return nil
+		}
+
+		retryable := false
+
+		var redirectErr *redirectError
+		var apiErr *Error
+		switch {
+		case errors.As(err, &redirectErr):
+			// the request was not handled, it is safe to send it to the leader
+			c.setLeader(redirectErr.leader)
+			retryable = true
+		case errors.As(err, &apiErr):
+			switch apiErr.StatusCode {
+			case http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+				// leadership may be changing
+				c.setLeader("")
+				retryable = ca.idempotent
+			}
+		default:
+			// transport error, the request was not sent if the connection was refused
+			c.setLeader("")
+			retryable = ca.idempotent || isDialError(err)
+		}
+
+		if !retryable || c.cfg.MaxRetries < 0 || attempt >= c.cfg.MaxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+
+		if wait *= 2; wait > maxRetryWait {
+			wait = maxRetryWait
+		}
+	}
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// readQuery returns the query of reads with configured consistency
+func (c *Client) readQuery() url.Values {
+	if c.cfg.Consistency == "" {
+		return nil
+	}
+
+	return url.Values{"consistency": []string{c.cfg.Consistency}}
+}
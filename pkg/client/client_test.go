@@ -0,0 +1,350 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/client"
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+// fakeNode answers with the envelope of util.SendApiResponse, handlers are keyed by "METHOD path"
+type fakeNode struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	handlers map[string]func(w http.ResponseWriter, r *http.Request)
+	calls    []string
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	n := &fakeNode{
+		handlers: map[string]func(w http.ResponseWriter, r *http.Request){},
+	}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + r.URL.Path
+
+		n.mutex.Lock()
+		n.calls = append(n.calls, route)
+		h := n.handlers[route]
+		n.mutex.Unlock()
+
+		if h == nil {
+			sendJSON(w, http.StatusNotFound, map[string]interface{}{"ok": false, "msg": "not found"})
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(n.Close)
+
+	return n
+}
+
+func (n *fakeNode) handle(route string, h func(w http.ResponseWriter, r *http.Request)) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.handlers[route] = h
+}
+
+func (n *fakeNode) count(route string) int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	count := 0
+	for _, c := range n.calls {
+		if c == route {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (n *fakeNode) addr() string {
+	return strings.TrimPrefix(n.URL, "http://")
+}
+
+// leadBy answers the leader endpoint with leader
+func (n *fakeNode) leadBy(leader *fakeNode) {
+	n.handle("GET /api/sys/leader", func(w http.ResponseWriter, r *http.Request) {
+		sendOK(w, &client.Node{ID: "leader", ApiAddr: leader.addr()})
+	})
+}
+
+func sendJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func sendOK(w http.ResponseWriter, data interface{}) {
+	sendJSON(w, http.StatusOK, map[string]interface{}{"ok": true, "data": data})
+}
+
+func sendError(w http.ResponseWriter, status int, msg string, err string, detail interface{}) {
+	sendJSON(w, status, map[string]interface{}{"ok": false, "msg": msg, "err": err, "detail": detail})
+}
+
+func newClient(t *testing.T, nodes ...*fakeNode) *client.Client {
+	var addrs []string
+	for _, n := range nodes {
+		addrs = append(addrs, n.URL)
+	}
+
+	c, err := client.New(client.Config{Addrs: addrs, RetryWait: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestLeaderDiscovery(t *testing.T) {
+	follower := newFakeNode(t)
+	leader := newFakeNode(t)
+	follower.leadBy(leader)
+
+	leader.handle("POST /api/v1/sessions", func(w http.ResponseWriter, r *http.Request) {
+		opts := &schema.SessionOptions{}
+		json.NewDecoder(r.Body).Decode(opts)
+		sendOK(w, schema.NewSession(opts))
+	})
+
+	c := newClient(t, follower)
+
+	session, err := c.StartSession(context.Background(), &schema.SessionOptions{Timeout: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Id == "" || session.Timeout != 60 {
+		t.Errorf("unexpected session %+v", session)
+	}
+	if follower.count("POST /api/v1/sessions") != 0 || leader.count("POST /api/v1/sessions") != 1 {
+		t.Errorf("expected session was started on leader, got follower %v leader %v", follower.calls, leader.calls)
+	}
+}
+
+func TestRedirectToLeader(t *testing.T) {
+	follower := newFakeNode(t)
+	leader := newFakeNode(t)
+
+	// leader is unknown by the follower at first, then it redirects
+	follower.handle("POST /api/v1/sessions/id/join", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", leader.URL+r.URL.Path)
+		sendError(w, http.StatusTemporaryRedirect, "redirect to leader", "not leader", &client.Node{ApiAddr: leader.addr()})
+	})
+	leader.handle("POST /api/v1/sessions/id/join", func(w http.ResponseWriter, r *http.Request) {
+		sendOK(w, &schema.Participant{Id: 1, SessionId: "id"})
+	})
+
+	c := newClient(t, follower)
+
+	part, err := c.JoinSession(context.Background(), "id", &schema.ParticipantJoinBody{ClientId: "client"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.Id != 1 || leader.count("POST /api/v1/sessions/id/join") != 1 {
+		t.Errorf("expected joined on leader, got %+v", part)
+	}
+}
+
+func TestErrorDecoding(t *testing.T) {
+	n := newFakeNode(t)
+	n.leadBy(n)
+
+	n.handle("POST /api/v1/sessions/id/commit", func(w http.ResponseWriter, r *http.Request) {
+		sendError(w, http.StatusConflict, "unable to commit session", "some participant voted abort, session was aborted",
+			&schema.Session{Id: "id", State: schema.SessionAborted})
+	})
+
+	c := newClient(t, n)
+
+	_, err := c.GetSession(context.Background(), "missing")
+	if !errors.Is(err, exception.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	_, err = c.CommitSession(context.Background(), "id")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, exception.ErrAborted) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+
+	session := &schema.Session{}
+	if err := apiErr.DecodeDetail(session); err != nil {
+		t.Fatal(err)
+	}
+	if session.State != schema.SessionAborted {
+		t.Errorf("expected aborted session in detail, got %+v", session)
+	}
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	n := newFakeNode(t)
+	n.leadBy(n)
+
+	failures := 2
+	unavailable := func(w http.ResponseWriter, r *http.Request) bool {
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+
+		if failures > 0 {
+			failures--
+			sendError(w, http.StatusServiceUnavailable, "unable to forward request to leader", "not leader", nil)
+			return true
+		}
+
+		return false
+	}
+
+	n.handle("GET /api/v1/sessions/id", func(w http.ResponseWriter, r *http.Request) {
+		if !unavailable(w, r) {
+			sendOK(w, &schema.Session{Id: "id"})
+		}
+	})
+	n.handle("POST /api/v1/sessions/id/abort", func(w http.ResponseWriter, r *http.Request) {
+		if !unavailable(w, r) {
+			sendOK(w, &schema.Session{Id: "id"})
+		}
+	})
+
+	c := newClient(t, n)
+
+	if _, err := c.GetSession(context.Background(), "id"); err != nil {
+		t.Fatal(err)
+	}
+	if count := n.count("GET /api/v1/sessions/id"); count != 3 {
+		t.Errorf("expected read was retried twice, got %v calls", count)
+	}
+
+	// abort may have been handled by leader, it is not retried
+	failures = 1
+	_, err := c.AbortSession(context.Background(), "id")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected unavailable error, got %v", err)
+	}
+	if count := n.count("POST /api/v1/sessions/id/abort"); count != 1 {
+		t.Errorf("expected abort was not retried, got %v calls", count)
+	}
+}
+
+func TestRetryConnectionRefused(t *testing.T) {
+	dead := newFakeNode(t)
+	dead.Close()
+
+	n := newFakeNode(t)
+	n.leadBy(n)
+	n.handle("POST /api/v1/sessions/id/forget", func(w http.ResponseWriter, r *http.Request) {
+		sendOK(w, &schema.Session{Id: "id", State: schema.SessionTerminated})
+	})
+
+	// the dead node is skipped by leader discovery
+	c := newClient(t, dead, n)
+
+	session, err := c.ForgetSession(context.Background(), "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.State != schema.SessionTerminated {
+		t.Errorf("unexpected session %+v", session)
+	}
+}
+
+func newSessionNode(t *testing.T) *fakeNode {
+	n := newFakeNode(t)
+	n.leadBy(n)
+
+	n.handle("POST /api/v1/sessions", func(w http.ResponseWriter, r *http.Request) {
+		sendOK(w, &schema.Session{Id: "id", State: schema.SessionStarted})
+	})
+	n.handle("POST /api/v1/sessions/id/join", func(w http.ResponseWriter, r *http.Request) {
+		sendOK(w, &schema.Participant{Id: 1, SessionId: "id"})
+	})
+	n.handle("POST /api/v1/sessions/id/commit", func(w http.ResponseWriter, r *http.Request) {
+		sendOK(w, &schema.Session{Id: "id", State: schema.SessionCommitted})
+	})
+	n.handle("POST /api/v1/sessions/id/abort", func(w http.ResponseWriter, r *http.Request) {
+		sendOK(w, &schema.Session{Id: "id", State: schema.SessionAborted})
+	})
+
+	return n
+}
+
+func TestWithSessionCommit(t *testing.T) {
+	n := newSessionNode(t)
+	c := newClient(t, n)
+
+	err := c.WithSession(context.Background(), func(s *client.Session) error {
+		_, err := s.Join(context.Background(), &schema.ParticipantJoinBody{ClientId: "client"})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n.count("POST /api/v1/sessions/id/commit") != 1 || n.count("POST /api/v1/sessions/id/abort") != 0 {
+		t.Errorf("expected session was committed, got %v", n.calls)
+	}
+}
+
+func TestWithSessionAbort(t *testing.T) {
+	n := newSessionNode(t)
+	c := newClient(t, n)
+
+	fnErr := errors.New("failed")
+	err := c.WithSession(context.Background(), func(s *client.Session) error {
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Errorf("expected error of fn, got %v", err)
+	}
+
+	if n.count("POST /api/v1/sessions/id/commit") != 0 || n.count("POST /api/v1/sessions/id/abort") != 1 {
+		t.Errorf("expected session was aborted, got %v", n.calls)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic was propagated")
+			}
+		}()
+
+		c.WithSession(context.Background(), func(s *client.Session) error {
+			panic("panic")
+		})
+	}()
+
+	if n.count("POST /api/v1/sessions/id/abort") != 2 {
+		t.Errorf("expected session was aborted on panic, got %v", n.calls)
+	}
+}
+
+func TestWithSessionAbortCanceled(t *testing.T) {
+	n := newSessionNode(t)
+	c := newClient(t, n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := c.WithSession(ctx, func(s *client.Session) error {
+		cancel()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error of fn, got %v", err)
+	}
+
+	// the session is still aborted after ctx of caller was canceled
+	if n.count("POST /api/v1/sessions/id/abort") != 1 {
+		t.Errorf("expected session was aborted, got %v", n.calls)
+	}
+}
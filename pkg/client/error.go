@@ -0,0 +1,71 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+)
+
+// envelope is the body of every api response, see util.SendApiResponse
+type envelope struct {
+	Ok     bool            `json:"ok"`
+	Data   json.RawMessage `json:"data"`
+	Msg    string          `json:"msg"`
+	Err    string          `json:"err"`
+	Detail json.RawMessage `json:"detail"`
+}
+
+// Error is an error response of the api, it matches the sentinel errors of exception by errors.Is.
+// eg: errors.Is(err, exception.ErrNotFound)
+type Error struct {
+	StatusCode int
+	Msg        string
+	Err        string
+	// raw json detail of the error, eg: the session of a failed commit
+	Detail json.RawMessage
+}
+
+func newError(statusCode int, env *envelope) *Error {
+	return &Error{
+		StatusCode: statusCode,
+		Msg:        env.Msg,
+		Err:        env.Err,
+		Detail:     env.Detail,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Err != "" {
+		return fmt.Sprintf("transcoorditor: %v (%v): %v", e.Msg, e.StatusCode, e.Err)
+	}
+
+	return fmt.Sprintf("transcoorditor: %v (%v)", e.Msg, e.StatusCode)
+}
+
+var statusErrors = map[int]error{
+	http.StatusBadRequest:          exception.ErrInvalidArgument,
+	http.StatusUnauthorized:        exception.ErrUnauthorized,
+	http.StatusForbidden:           exception.ErrForbidden,
+	http.StatusNotFound:            exception.ErrNotFound,
+	http.StatusConflict:            exception.ErrAborted,
+	http.StatusGone:                exception.ErrGone,
+	http.StatusPreconditionFailed:  exception.ErrPreconditionFailed,
+	http.StatusUnprocessableEntity: exception.ErrPreconditionFailed,
+}
+
+func (e *Error) Is(target error) bool {
+	err, ok := statusErrors[e.StatusCode]
+
+	return ok && err == target
+}
+
+// DecodeDetail decodes the detail of error into v
+func (e *Error) DecodeDetail(v interface{}) error {
+	if len(e.Detail) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(e.Detail, v)
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+func lockPath(key string, action string) string {
+	path := "/api/v1/locks/" + url.PathEscape(key)
+	if action != "" {
+		path += "/" + action
+	}
+
+	return path
+}
+
+func (c *Client) ListLock(ctx context.Context) ([]*schema.LockEntry, error) {
+	var locks []*schema.LockEntry
+	err := c.do(ctx, &call{method: http.MethodGet, path: "/api/v1/locks", query: c.readQuery(), idempotent: true}, &locks)
+	if err != nil {
+		return nil, err
+	}
+
+	return locks, nil
+}
+
+func (c *Client) GetLock(ctx context.Context, key string) (*schema.LockEntry, error) {
+	lockEnt := &schema.LockEntry{}
+	err := c.do(ctx, &call{method: http.MethodGet, path: lockPath(key, ""), query: c.readQuery(), idempotent: true}, lockEnt)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockEnt, nil
+}
+
+// AcquireLock acquires the lock for owner, the owner is able to re-acquire its lock so it is retried
+func (c *Client) AcquireLock(ctx context.Context, key string, body *schema.LockAcquireBody) (*schema.LockEntry, error) {
+	lockEnt := &schema.LockEntry{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: lockPath(key, "acquire"), body: body, idempotent: true}, lockEnt)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockEnt, nil
+}
+
+func (c *Client) ExtendLock(ctx context.Context, key string, body *schema.LockAcquireBody) (*schema.LockEntry, error) {
+	lockEnt := &schema.LockEntry{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: lockPath(key, "extend"), body: body}, lockEnt)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockEnt, nil
+}
+
+func (c *Client) ReleaseLock(ctx context.Context, key string, owner string) error {
+	body := &schema.LockReleaseBody{Owner: owner}
+
+	return c.do(ctx, &call{method: http.MethodPost, path: lockPath(key, "release"), body: body, idempotent: true}, nil)
+}
@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+func sessionPath(id string, action string) string {
+	path := "/api/v1/sessions/" + url.PathEscape(id)
+	if action != "" {
+		path += "/" + action
+	}
+
+	return path
+}
+
+func (c *Client) StartSession(ctx context.Context, opts *schema.SessionOptions) (*schema.Session, error) {
+	session := &schema.Session{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: "/api/v1/sessions", body: opts}, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (c *Client) GetSession(ctx context.Context, id string) (*schema.Session, error) {
+	session := &schema.Session{}
+	err := c.do(ctx, &call{method: http.MethodGet, path: sessionPath(id, ""), query: c.readQuery(), idempotent: true}, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (c *Client) ListSession(ctx context.Context) ([]*schema.Session, error) {
+	var sessions []*schema.Session
+	err := c.do(ctx, &call{method: http.MethodGet, path: "/api/v1/sessions", query: c.readQuery(), idempotent: true}, &sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (c *Client) PutSession(ctx context.Context, session *schema.Session) (*schema.Session, error) {
+	out := &schema.Session{}
+	err := c.do(ctx, &call{method: http.MethodPut, path: sessionPath(session.Id, ""), body: session, idempotent: true}, out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *Client) DeleteSession(ctx context.Context, id string) (*schema.Session, error) {
+	session := &schema.Session{}
+	err := c.do(ctx, &call{method: http.MethodDelete, path: sessionPath(id, "")}, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (c *Client) JoinSession(ctx context.Context, id string, body *schema.ParticipantJoinBody) (*schema.Participant, error) {
+	part := &schema.Participant{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: sessionPath(id, "join"), body: body}, part)
+	if err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
+
+func (c *Client) PartialCommit(ctx context.Context, id string, commit *schema.ParticipantCommit) (*schema.Participant, error) {
+	part := &schema.Participant{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: sessionPath(id, "partial-commit"), body: commit}, part)
+	if err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
+
+func (c *Client) HeartbeatParticipant(ctx context.Context, id string, participantId int64) (*schema.Participant, error) {
+	part := &schema.Participant{}
+	path := sessionPath(id, fmt.Sprintf("participants/%v/heartbeat", participantId))
+	err := c.do(ctx, &call{method: http.MethodPost, path: path, idempotent: true}, part)
+	if err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
+
+// KeepAliveSession extends the session deadline to timeout seconds from now
+func (c *Client) KeepAliveSession(ctx context.Context, id string, timeout int) (*schema.Session, error) {
+	session := &schema.Session{}
+	body := &schema.SessionKeepAliveBody{Timeout: timeout}
+	err := c.do(ctx, &call{method: http.MethodPost, path: sessionPath(id, "keepalive"), body: body, idempotent: true}, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// CommitSession commits the session, committed session is returned as it is
+func (c *Client) CommitSession(ctx context.Context, id string) (*schema.Session, error) {
+	session := &schema.Session{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: sessionPath(id, "commit"), idempotent: true}, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (c *Client) AbortSession(ctx context.Context, id string) (*schema.Session, error) {
+	session := &schema.Session{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: sessionPath(id, "abort")}, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (c *Client) ForgetSession(ctx context.Context, id string) (*schema.Session, error) {
+	session := &schema.Session{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: sessionPath(id, "forget")}, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Session is a started session bound to the client
+type Session struct {
+	*schema.Session
+	c *Client
+}
+
+func (s *Session) Join(ctx context.Context, body *schema.ParticipantJoinBody) (*schema.Participant, error) {
+	return s.c.JoinSession(ctx, s.Id, body)
+}
+
+func (s *Session) PartialCommit(ctx context.Context, commit *schema.ParticipantCommit) (*schema.Participant, error) {
+	return s.c.PartialCommit(ctx, s.Id, commit)
+}
+
+func (s *Session) Heartbeat(ctx context.Context, participantId int64) (*schema.Participant, error) {
+	return s.c.HeartbeatParticipant(ctx, s.Id, participantId)
+}
+
+func (s *Session) KeepAlive(ctx context.Context, timeout int) (*schema.Session, error) {
+	return s.c.KeepAliveSession(ctx, s.Id, timeout)
+}
+
+// WithSession starts a session with default options, then commits it if fn succeeded or aborts it otherwise
+func (c *Client) WithSession(ctx context.Context, fn func(s *Session) error) error {
+	return c.WithSessionOptions(ctx, schema.NewSessionOption(), fn)
+}
+
+// WithSessionOptions is the same as WithSession with the given options. The error of fn is returned
+// after the session was aborted, the session is also aborted if fn panics or ctx is canceled
+func (c *Client) WithSessionOptions(ctx context.Context, opts *schema.SessionOptions, fn func(s *Session) error) (err error) {
+	session, err := c.StartSession(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	s := &Session{Session: session, c: c}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+
+		// ctx may have been canceled, which is often why fn failed
+		abortCtx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+		defer cancel()
+
+		// the session is timed out by coordinator if it can not be aborted
+		if _, abortErr := c.AbortSession(abortCtx, s.Id); abortErr != nil && err != nil {
+			err = fmt.Errorf("%w, abort session failed: %v", err, abortErr)
+		}
+	}()
+
+	if err = fn(s); err != nil {
+		return err
+	}
+
+	committed = true
+	_, err = c.CommitSession(ctx, s.Id)
+
+	return err
+}
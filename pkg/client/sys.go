@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Node is a node of the cluster, it is the json form of Node
+type Node struct {
+	ID   string `json:"ID"`
+	Host string `json:"Host"`
+	Addr string `json:"Addr"`
+	// http api address of the node
+	ApiAddr string `json:"ApiAddr"`
+	// grpc api address of the node
+	GrpcAddr string `json:"GrpcAddr"`
+}
+
+// ClusterRsConf is the json form of ClusterRsConf
+type ClusterRsConf struct {
+	RsName  string  `json:"RsName"`
+	Nodes   []*Node `json:"Nodes"`
+	Leader  *Node   `json:"Leader"`
+	Current *Node   `json:"Current"`
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, &call{method: http.MethodGet, path: "/api/sys/ping", idempotent: true}, nil)
+}
+
+func (c *Client) GetClusterRsConf(ctx context.Context) (*ClusterRsConf, error) {
+	conf := &ClusterRsConf{}
+	err := c.do(ctx, &call{method: http.MethodGet, path: "/api/sys/rsconf", idempotent: true}, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func (c *Client) GetClusterStats(ctx context.Context) (map[string]string, error) {
+	var stats map[string]string
+	err := c.do(ctx, &call{method: http.MethodGet, path: "/api/sys/stats", idempotent: true}, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (c *Client) GetClusterLeader(ctx context.Context) (*Node, error) {
+	node := &Node{}
+	err := c.do(ctx, &call{method: http.MethodGet, path: "/api/sys/leader", idempotent: true}, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (c *Client) JoinCluster(ctx context.Context, node *Node) (*ClusterRsConf, error) {
+	conf := &ClusterRsConf{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: "/api/sys/join", body: node}, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func (c *Client) LeftCluster(ctx context.Context, node *Node) (*ClusterRsConf, error) {
+	conf := &ClusterRsConf{}
+	err := c.do(ctx, &call{method: http.MethodPost, path: "/api/sys/left", body: node}, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"encoding/json"
+	"io"
 
 	"github.com/hashicorp/raft"
 )
@@ -10,6 +11,16 @@ type Applier interface {
 	// GetLastIndex()
 	// GetLastTerm()
 	Apply(c *Command, log *raft.Log) *ApplyResponse
+	// Snapshot captures the current state of applier, it is never called concurrently with Apply
+	// but the returned ApplierSnapshot is persisted concurrently with Apply
+	Snapshot() (ApplierSnapshot, error)
+	// Restore discards the current state of applier and replaces it with the one read from snapshot
+	Restore(r io.Reader) error
+}
+
+type ApplierSnapshot interface {
+	Persist(w io.Writer) error
+	Release()
 }
 
 type ApplyResponse struct {
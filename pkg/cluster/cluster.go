@@ -30,6 +30,10 @@ type Node struct {
 	ID   string
 	Host string
 	Addr string
+	// http api address of the node, see nodeApiAddr
+	ApiAddr string
+	// grpc api address of the node, see nodeGrpcAddr
+	GrpcAddr string
 }
 
 func (n *Node) id() raft.ServerID {
@@ -40,6 +44,41 @@ func (n *Node) addr() raft.ServerAddress {
 	return raft.ServerAddress(n.Host)
 }
 
+// the http api of a node is expected to listen on the same host as its raft transport,
+// on the CLUSTER_API_PORT (defaults to PORT of current node). Nodes do not advertise their api
+// addresses, so every node of the cluster must be configured with the same ports
+func nodeApiAddr(raftAddr raft.ServerAddress) string {
+	host, _, err := net.SplitHostPort(string(raftAddr))
+	if err != nil {
+		return ""
+	}
+
+	port := viper.GetString("CLUSTER_API_PORT")
+	if port == "" {
+		port = viper.GetString("PORT")
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
+// the same as nodeApiAddr, the grpc api is expected to listen on the CLUSTER_GRPC_PORT (defaults to GRPC_PORT)
+func nodeGrpcAddr(raftAddr raft.ServerAddress) string {
+	host, _, err := net.SplitHostPort(string(raftAddr))
+	if err != nil {
+		return ""
+	}
+
+	port := viper.GetString("CLUSTER_GRPC_PORT")
+	if port == "" {
+		port = viper.GetString("GRPC_PORT")
+	}
+	if port == "" {
+		return ""
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
 type ClusterRsConf struct {
 	RsName  string
 	Nodes   []*Node
@@ -183,8 +222,10 @@ func (c *Cluster) GetConf() (*ClusterRsConf, error) {
 	leaderAddr := string(c.Ra.Leader())
 	for _, server := range raftConf.Servers {
 		n := &Node{
-			ID:   string(server.ID),
-			Host: string(server.Address),
+			ID:       string(server.ID),
+			Host:     string(server.Address),
+			ApiAddr:  nodeApiAddr(server.Address),
+			GrpcAddr: nodeGrpcAddr(server.Address),
 		}
 		rsconf.Nodes = append(rsconf.Nodes, n)
 
@@ -230,6 +271,30 @@ func (c *Cluster) VerifyLeader() error {
 	return nil
 }
 
+// ReadBarrier ensures that a following read from local store is linearizable,
+// leadership is confirmed and every committed log has been applied to local store
+func (c *Cluster) ReadBarrier(timeout time.Duration) error {
+	if err := c.AssertRunning(); err != nil {
+		return err
+	}
+
+	if err := c.AssertLeader(); err != nil {
+		return err
+	}
+
+	if err := c.VerifyLeader(); err != nil {
+		return err
+	}
+
+	// fast path, whole log of leader (which contains every committed log) has been applied
+	if c.Ra.AppliedIndex() >= c.Ra.LastIndex() {
+		return nil
+	}
+
+	// wait for in-flight logs are committed and applied
+	return c.Ra.Barrier(timeout).Error()
+}
+
 func (c *Cluster) Leader() (*Node, error) {
 	conf, err := c.GetConf()
 	if err != nil {
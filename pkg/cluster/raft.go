@@ -160,26 +160,45 @@ func (f *fsm) Apply(log *raft.Log) interface{} {
 }
 
 func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
-	fmt.Println("[fsm] Snapshot")
-	return &fsmSnapshot{}, nil
+	logger.Debug(" + [fsm] Snapshot")
+	if f.ap == nil {
+		return nil, ErrNoApplier
+	}
+
+	snap, err := f.ap.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fsmSnapshot{
+		snap: snap,
+	}, nil
 }
 
 func (f *fsm) Restore(snapshot io.ReadCloser) error {
-	fmt.Println("[fsm] Restore")
-	return nil
+	logger.Debug(" + [fsm] Restore")
+	defer snapshot.Close()
+
+	if f.ap == nil {
+		return ErrNoApplier
+	}
+
+	return f.ap.Restore(snapshot)
 }
 
 type fsmSnapshot struct {
+	snap ApplierSnapshot
 }
 
 func (f *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
-	_, err := sink.Write([]byte(""))
-	if err != nil {
+	if err := f.snap.Persist(sink); err != nil {
+		sink.Cancel()
 		return err
 	}
 
-	return nil
+	return sink.Close()
 }
 
 func (f *fsmSnapshot) Release() {
+	f.snap.Release()
 }
@@ -3,6 +3,7 @@ package cluster_test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"testing"
 	"time"
@@ -29,6 +30,35 @@ func (a *applier) Apply(cmd *cluster.Command, log *raft.Log) *cluster.ApplyRespo
 
 }
 
+type applierSnapshot struct {
+	kv map[string]string
+}
+
+func (s *applierSnapshot) Persist(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s.kv)
+}
+
+func (s *applierSnapshot) Release() {}
+
+func (a *applier) Snapshot() (cluster.ApplierSnapshot, error) {
+	kv := make(map[string]string, len(a.kv))
+	for k, v := range a.kv {
+		kv[k] = v
+	}
+
+	return &applierSnapshot{kv: kv}, nil
+}
+
+func (a *applier) Restore(r io.Reader) error {
+	kv := make(map[string]string)
+	if err := json.NewDecoder(r).Decode(&kv); err != nil {
+		return err
+	}
+	a.kv = kv
+
+	return nil
+}
+
 func defaultRaConfig(kv map[string]string) *cluster.RaftConfig {
 	return &cluster.RaftConfig{
 		Ap: &applier{
@@ -13,6 +13,11 @@ func loadDefaults() {
 	viper.SetDefault("PORT", "8000")
 	viper.SetDefault("SERVER_READ_TIMEOUT", "300")
 
+	// for gRPC Server
+	viper.SetDefault("GRPC_PORT", "9000")
+	// serve the cluster membership service (rpc.System) on the grpc port, like /api/sys it must not be exposed publicly
+	viper.SetDefault("GRPC_SYSTEM_ENABLED", "false")
+
 	// mongodb
 	viper.SetDefault("MONGODB_URI", "mongodb://localhost:27017")
 	viper.SetDefault("MONGODB_DB", "transcoorditor")
@@ -20,9 +25,26 @@ func loadDefaults() {
 	// boltdb
 	viper.SetDefault("BOLTDB_PATH", "bolt.db")
 
+	// session
+	// maximum seconds a keepalive is able to extend the session deadline from now
+	viper.SetDefault("SESSION_KEEPALIVE_MAX_TIMEOUT", "3600")
+	// milliseconds before its expiry a reservation of TCC session is proactively cancelled
+	viper.SetDefault("TCC_CANCEL_MARGIN_MS", "5000")
+	// participant actions and session notifications are signed by this secret if it is set, see schema.SignAction
+	viper.SetDefault("ACTION_SIGNING_SECRET", "")
+	// delivery of session state to notification urls, see schema.SessionNotification
+	viper.SetDefault("NOTIFY_TIMEOUT_MS", "5000")
+	viper.SetDefault("NOTIFY_MAX_ATTEMPTS", "10")
+
 	// cluster
 	viper.SetDefault("NODE_ADDR", "localhost:7000")
 	viper.SetDefault("NODE_ID", "local")
+	// how a follower handles write requests: "proxy" forwards them to the leader, "redirect" answers 307 with leader address
+	viper.SetDefault("LEADER_FORWARD", "proxy")
+	// ports of http and grpc api which every node listens on, addresses of other nodes are derived from
+	// their raft host and these ports, so all nodes must use the same ones. Empty means PORT and GRPC_PORT
+	viper.SetDefault("CLUSTER_API_PORT", "")
+	viper.SetDefault("CLUSTER_GRPC_PORT", "")
 
 }
 
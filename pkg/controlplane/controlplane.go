@@ -39,7 +39,8 @@ func New(c *cluster.Cluster) *ControlPlane {
 	}
 }
 
-func (ctrl *ControlPlane) watchClusterLeader(leaderCh <-chan bool) {
+// watchClusterLeader is given the stopCh of Run, since ctrl.stopCh is reset by Stop
+func (ctrl *ControlPlane) watchClusterLeader(leaderCh <-chan bool, stopCh <-chan struct{}) {
 	// do we need to check leadership before watch on leaderCh for manually start reconciler?
 	logger.Info("Watch leadership...")
 
@@ -47,6 +48,14 @@ func (ctrl *ControlPlane) watchClusterLeader(leaderCh <-chan bool) {
 		select {
 		case isLeader := <-leaderCh:
 			ctrl.mutex.Lock()
+			// stopped while waiting for the lock, reconcilers must not be started again
+			select {
+			case <-stopCh:
+				ctrl.mutex.Unlock()
+				return
+			default:
+			}
+
 			if isLeader {
 				logger.Info("[+] on Leader")
 				ctrl.unsafeStartReconciler()
@@ -55,7 +64,7 @@ func (ctrl *ControlPlane) watchClusterLeader(leaderCh <-chan bool) {
 				ctrl.unsafeStopReconciler()
 			}
 			ctrl.mutex.Unlock()
-		case <-*ctrl.stopCh:
+		case <-stopCh:
 			// the stopCh's sender must manully stop reconciling
 			return
 		}
@@ -93,7 +102,7 @@ func (ctrl *ControlPlane) Run() error {
 	ctrl.stopCh = &stop
 	logger.Info("Running...")
 
-	go ctrl.watchClusterLeader(leaderCh)
+	go ctrl.watchClusterLeader(leaderCh, stop)
 
 	return nil
 }
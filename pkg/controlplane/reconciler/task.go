@@ -0,0 +1,56 @@
+package reconciler
+
+import (
+	"sync"
+)
+
+type TaskFunc func(stopCh <-chan struct{})
+
+// TaskReconciler runs its task once each time the reconciler is started (eg: on gaining leadership),
+// the task should return early when stopCh is closed
+type TaskReconciler struct {
+	task TaskFunc
+
+	mutex  sync.Mutex
+	waitCh *chan struct{}
+}
+
+func NewTaskReconciler(task TaskFunc) *TaskReconciler {
+	return &TaskReconciler{
+		task: task,
+	}
+}
+
+func (r *TaskReconciler) Bootstrap() {
+}
+
+// this should running inside an goroutine and call once at a time
+func (r *TaskReconciler) Reconcile(stopCh <-chan struct{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	waitCh := make(chan struct{})
+	r.waitCh = &waitCh
+
+	go func() {
+		defer close(waitCh)
+
+		logger.Info("Start task...")
+		r.task(stopCh)
+		logger.Info("Task done!")
+	}()
+}
+
+func (r *TaskReconciler) WaitStop() <-chan struct{} {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.waitCh == nil {
+		stopped := make(chan struct{})
+		close(stopped)
+
+		return stopped
+	}
+
+	return *r.waitCh
+}
@@ -0,0 +1,44 @@
+package reconciler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/controlplane"
+	"github.com/barrydevp/transcoorditor/pkg/controlplane/reconciler"
+)
+
+func TestTaskReconciler(t *testing.T) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	recl := reconciler.NewTaskReconciler(func(stopCh <-chan struct{}) {
+		close(done)
+		<-stopCh
+		close(stopped)
+	})
+
+	ctrlplane := controlplane.New(nil)
+	ctrlplane.RegisterRecl(recl)
+	ctrlplane.Run()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("task was not run on leadership")
+	}
+
+	ctrlplane.Stop()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("task was not stopped")
+	}
+
+	select {
+	case <-recl.WaitStop():
+	case <-time.After(5 * time.Second):
+		t.Fatal("reconciler was not stopped")
+	}
+}
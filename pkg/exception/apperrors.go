@@ -53,6 +53,10 @@ func (r *AppError) Err() error {
 	return r.err
 }
 
+func (r *AppError) Unwrap() error {
+	return r.err
+}
+
 func (r *AppError) Error() string {
 	if r.err != nil {
 		return r.err.Error()
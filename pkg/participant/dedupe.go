@@ -0,0 +1,103 @@
+package participant
+
+import (
+	"sync"
+	"time"
+)
+
+type DedupeState int
+
+const (
+	// the invocation is new, the caller must call Done or Release after handling it
+	DedupeNew DedupeState = iota
+	DedupeInProgress
+	DedupeDone
+)
+
+// Deduper remembers invocations by their id
+type Deduper interface {
+	// Begin marks the invocation as in progress if it is new
+	Begin(invocationId string) DedupeState
+	// Done marks the invocation as done, retries of it are not handled again
+	Done(invocationId string)
+	// Release forgets the invocation, so a retry of it is handled again
+	Release(invocationId string)
+}
+
+type memoryEntry struct {
+	done      bool
+	expiredAt time.Time
+}
+
+type expiry struct {
+	invocationId string
+	expiredAt    time.Time
+}
+
+type memoryDeduper struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*memoryEntry
+	// done invocations in the order of their expiry, the ttl is the same for all of them
+	expiries []expiry
+}
+
+// NewMemoryDeduper returns a Deduper remembering done invocations in memory for ttl
+func NewMemoryDeduper(ttl time.Duration) Deduper {
+	return &memoryDeduper{
+		ttl:     ttl,
+		entries: map[string]*memoryEntry{},
+	}
+}
+
+func (d *memoryDeduper) Begin(invocationId string) DedupeState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.evict(now)
+
+	if entry, ok := d.entries[invocationId]; ok {
+		if entry.done {
+			return DedupeDone
+		}
+		return DedupeInProgress
+	}
+
+	d.entries[invocationId] = &memoryEntry{}
+
+	return DedupeNew
+}
+
+func (d *memoryDeduper) Done(invocationId string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expiredAt := time.Now().Add(d.ttl)
+	d.entries[invocationId] = &memoryEntry{
+		done:      true,
+		expiredAt: expiredAt,
+	}
+	d.expiries = append(d.expiries, expiry{invocationId, expiredAt})
+}
+
+func (d *memoryDeduper) Release(invocationId string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.entries, invocationId)
+}
+
+// evict removes expired done invocations from the front of expiries, in progress invocations never expire
+func (d *memoryDeduper) evict(now time.Time) {
+	n := 0
+	for ; n < len(d.expiries) && now.After(d.expiries[n].expiredAt); n++ {
+		ex := d.expiries[n]
+		if entry, ok := d.entries[ex.invocationId]; ok && entry.done && entry.expiredAt.Equal(ex.expiredAt) {
+			delete(d.entries, ex.invocationId)
+		}
+	}
+
+	// the evicted front is freed when append grows the slice
+	d.expiries = d.expiries[n:]
+}
@@ -0,0 +1,60 @@
+package participant
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ServeHTTP handles an action sent by the coordinator
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := h.serve(r.Context(), r.Header, body)
+	writeResponse(w, status, err)
+}
+
+func writeResponse(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(newResponse(err))
+}
+
+// Middleware handles the actions sent by the coordinator and passes other requests to next
+func (h *Handler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsAction(r.Header) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Fiber returns a fiber handler of the actions, other requests are passed to the next handler
+func (h *Handler) Fiber() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := http.Header{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			header.Add(string(key), string(value))
+		})
+
+		if !IsAction(header) {
+			return c.Next()
+		}
+
+		// fiber reuses the body buffer after the handler returned
+		body := append([]byte(nil), c.Body()...)
+
+		status, err := h.serve(c.UserContext(), header, body)
+
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Status(status).Send(newResponse(err))
+	}
+}
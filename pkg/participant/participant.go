@@ -0,0 +1,261 @@
+// Package participant helps participants to serve complete/compensate/prepare actions invoked by the coordinator.
+//
+//	h := participant.New(participant.Config{Secret: os.Getenv("ACTION_SIGNING_SECRET")})
+//	h.Complete("charge", func(ctx context.Context, inv *participant.Invocation) error { ... })
+//	h.Compensate("charge", func(ctx context.Context, inv *participant.Invocation) error { ... })
+//
+//	http.Handle("/actions", h)     // net/http
+//	app.Post("/actions", h.Fiber()) // fiber
+package participant
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+var (
+	ErrMissingHeader    = errors.New("missing action header")
+	ErrInvalidSignature = errors.New("invalid action signature")
+	ErrNoHandler        = errors.New("no handler for action")
+	ErrInProgress       = errors.New("action is in progress")
+)
+
+const (
+	defaultTolerance = 5 * time.Minute
+	defaultDedupeTTL = 24 * time.Hour
+)
+
+// Invocation is an action invoked by the coordinator
+type Invocation struct {
+	SessionId     string
+	ParticipantId int64
+	Kind          schema.ActionKind
+	// name of the action, see schema.ParticipantAction.Name
+	Name string
+	// the same on every retry of the action
+	InvocationId string
+	// starts from 1
+	Attempt int
+	// fencing token of the session, 0 if the session was not started with a lock
+	FencingToken uint64
+	Header       http.Header
+	// raw data of the action, see schema.ParticipantAction.Data
+	Body []byte
+}
+
+// Bind decodes data of the action into v
+func (inv *Invocation) Bind(v interface{}) error {
+	return json.Unmarshal(inv.Body, v)
+}
+
+// HandlerFunc handles an action, the coordinator retries the action if it returns an error.
+// Returning an error from a prepare handler votes abort.
+type HandlerFunc func(ctx context.Context, inv *Invocation) error
+
+type Config struct {
+	// verify the signature of actions if it is set, must be the ACTION_SIGNING_SECRET of the coordinator
+	Secret string
+	// max age of a signed action, 5 minutes by default
+	Tolerance time.Duration
+	// completed invocations are remembered for DedupeTTL, 24 hours by default.
+	// Ignored if Deduper is set.
+	DedupeTTL time.Duration
+	// in memory by default, set it to share deduplication between instances of participant
+	Deduper Deduper
+}
+
+// Handler dispatches actions to the handlers registered by kind and name
+type Handler struct {
+	mu       sync.RWMutex
+	handlers map[schema.ActionKind]map[string]HandlerFunc
+
+	secret    string
+	tolerance time.Duration
+	deduper   Deduper
+}
+
+func New(cfg Config) *Handler {
+	h := &Handler{
+		handlers:  map[schema.ActionKind]map[string]HandlerFunc{},
+		secret:    cfg.Secret,
+		tolerance: cfg.Tolerance,
+		deduper:   cfg.Deduper,
+	}
+
+	if h.tolerance <= 0 {
+		h.tolerance = defaultTolerance
+	}
+
+	if h.deduper == nil {
+		ttl := cfg.DedupeTTL
+		if ttl <= 0 {
+			ttl = defaultDedupeTTL
+		}
+		h.deduper = NewMemoryDeduper(ttl)
+	}
+
+	return h
+}
+
+// Handle registers fn for the actions of kind with the given name,
+// name is empty for the actions without name.
+func (h *Handler) Handle(kind schema.ActionKind, name string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.handlers[kind] == nil {
+		h.handlers[kind] = map[string]HandlerFunc{}
+	}
+	h.handlers[kind][name] = fn
+}
+
+func (h *Handler) Complete(name string, fn HandlerFunc) {
+	h.Handle(schema.ActionComplete, name, fn)
+}
+
+func (h *Handler) Compensate(name string, fn HandlerFunc) {
+	h.Handle(schema.ActionCompensate, name, fn)
+}
+
+func (h *Handler) Prepare(name string, fn HandlerFunc) {
+	h.Handle(schema.ActionPrepare, name, fn)
+}
+
+func (h *Handler) handler(kind schema.ActionKind, name string) HandlerFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.handlers[kind][name]
+}
+
+// IsAction reports whether the request was sent by the coordinator
+func IsAction(header http.Header) bool {
+	return header.Get(schema.InvocationIdHeader) != ""
+}
+
+func parseInvocation(header http.Header, body []byte) (*Invocation, error) {
+	inv := &Invocation{
+		SessionId:    header.Get(schema.SessionIdHeader),
+		Kind:         schema.ActionKind(header.Get(schema.ActionKindHeader)),
+		Name:         header.Get(schema.ActionNameHeader),
+		InvocationId: header.Get(schema.InvocationIdHeader),
+		Header:       header,
+		Body:         body,
+	}
+
+	if inv.SessionId == "" || inv.Kind == "" || inv.InvocationId == "" {
+		return nil, ErrMissingHeader
+	}
+
+	var err error
+	if inv.ParticipantId, err = strconv.ParseInt(header.Get(schema.ParticipantIdHeader), 10, 64); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMissingHeader, schema.ParticipantIdHeader)
+	}
+
+	if attempt := header.Get(schema.AttemptHeader); attempt != "" {
+		inv.Attempt, _ = strconv.Atoi(attempt)
+	}
+
+	if token := header.Get(schema.FencingTokenHeader); token != "" {
+		inv.FencingToken, _ = strconv.ParseUint(token, 10, 64)
+	}
+
+	return inv, nil
+}
+
+func (h *Handler) verify(inv *Invocation) error {
+	if h.secret == "" {
+		return nil
+	}
+
+	timestamp := inv.Header.Get(schema.TimestampHeader)
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if age := time.Since(time.Unix(sec, 0)); age > h.tolerance || age < -h.tolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := schema.SignAction(h.secret, timestamp, inv.InvocationId, inv.Body)
+	if !hmac.Equal([]byte(expected), []byte(inv.Header.Get(schema.SignatureHeader))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// serve handles an action and returns the status code for the coordinator:
+//   - 200 the action is done, including a retry of a done action
+//   - 400 the request is not an action
+//   - 401 the signature is invalid
+//   - 404 no handler was registered for the action
+//   - 409 the same invocation is in progress, the coordinator retries it later
+//   - 500 the handler returned an error, the coordinator retries it later
+func (h *Handler) serve(ctx context.Context, header http.Header, body []byte) (int, error) {
+	inv, err := parseInvocation(header, body)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := h.verify(inv); err != nil {
+		return http.StatusUnauthorized, err
+	}
+
+	fn := h.handler(inv.Kind, inv.Name)
+	if fn == nil {
+		return http.StatusNotFound, fmt.Errorf("%w: %v %q", ErrNoHandler, inv.Kind, inv.Name)
+	}
+
+	switch h.deduper.Begin(inv.InvocationId) {
+	case DedupeDone:
+		return http.StatusOK, nil
+	case DedupeInProgress:
+		return http.StatusConflict, ErrInProgress
+	}
+
+	handled := false
+	defer func() {
+		// fn has panicked, release the invocation so a retry of it is handled again, the panic goes on
+		if !handled {
+			h.deduper.Release(inv.InvocationId)
+		}
+	}()
+
+	err = fn(ctx, inv)
+	handled = true
+	if err != nil {
+		h.deduper.Release(inv.InvocationId)
+		return http.StatusInternalServerError, err
+	}
+
+	h.deduper.Done(inv.InvocationId)
+
+	return http.StatusOK, nil
+}
+
+type response struct {
+	Ok  bool   `json:"ok"`
+	Err string `json:"err,omitempty"`
+}
+
+func newResponse(err error) []byte {
+	resp := &response{Ok: err == nil}
+	if err != nil {
+		resp.Err = err.Error()
+	}
+
+	b, _ := json.Marshal(resp)
+
+	return b
+}
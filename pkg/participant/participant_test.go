@@ -0,0 +1,226 @@
+package participant_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/participant"
+	"github.com/barrydevp/transcoorditor/pkg/participant/participanttest"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/gofiber/fiber/v2"
+)
+
+type order struct {
+	OrderId string `json:"orderId"`
+}
+
+func TestHandlerComplete(t *testing.T) {
+	h := participant.New(participant.Config{})
+
+	var got *participant.Invocation
+	var data order
+	h.Complete("charge", func(ctx context.Context, inv *participant.Invocation) error {
+		got = inv
+		return inv.Bind(&data)
+	})
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	coord := participanttest.NewCoordinator(srv.URL)
+	res, err := coord.Complete("charge", &order{OrderId: "o-1"})
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status code = %v, want 200", res.StatusCode)
+	}
+
+	if got.SessionId != coord.Session.Id || got.ParticipantId != coord.Part.Id {
+		t.Fatalf("invocation = %v/%v, want %v/%v", got.SessionId, got.ParticipantId, coord.Session.Id, coord.Part.Id)
+	}
+	if got.Kind != schema.ActionComplete || got.Attempt != 1 {
+		t.Fatalf("kind = %v, attempt = %v", got.Kind, got.Attempt)
+	}
+	if got.InvocationId != schema.InvocationId(coord.Session.Id, coord.Part.Id, schema.ActionComplete) {
+		t.Fatalf("invocation id = %v", got.InvocationId)
+	}
+	if data.OrderId != "o-1" {
+		t.Fatalf("data = %+v", data)
+	}
+}
+
+func TestHandlerDedupe(t *testing.T) {
+	h := participant.New(participant.Config{})
+
+	var calls int32
+	h.Compensate("charge", func(ctx context.Context, inv *participant.Invocation) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return errors.New("refund failed")
+		}
+		return nil
+	})
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	coord := participanttest.NewCoordinator(srv.URL)
+	action := coord.Action("charge", nil)
+
+	// failed invocation is handled again on retry
+	if res, err := coord.Invoke(schema.ActionCompensate, action); err == nil || res.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("first attempt: status code = %v, err = %v", res.StatusCode, err)
+	}
+	if _, err := coord.Invoke(schema.ActionCompensate, action); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+
+	// done invocation is not handled again
+	if res, err := coord.Redeliver(schema.ActionCompensate, action); err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("redeliver: status code = %v, err = %v", res.StatusCode, err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("calls = %v, want 2", calls)
+	}
+}
+
+func TestHandlerPanic(t *testing.T) {
+	h := participant.New(participant.Config{})
+
+	var calls int32
+	h.Complete("charge", func(ctx context.Context, inv *participant.Invocation) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			panic("charge failed")
+		}
+		return nil
+	})
+
+	srv := httptest.NewUnstartedServer(h)
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.Start()
+	defer srv.Close()
+
+	coord := participanttest.NewCoordinator(srv.URL)
+	action := coord.Action("charge", nil)
+
+	if _, err := coord.Invoke(schema.ActionComplete, action); err == nil {
+		t.Fatalf("expected panicked invocation failed")
+	}
+	// panicked invocation is not left in progress
+	if res, err := coord.Invoke(schema.ActionComplete, action); err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("retry: status code = %v, err = %v", res.StatusCode, err)
+	}
+}
+
+func TestMemoryDeduperExpiry(t *testing.T) {
+	d := participant.NewMemoryDeduper(10 * time.Millisecond)
+
+	if state := d.Begin("a"); state != participant.DedupeNew {
+		t.Fatalf("state = %v, want new", state)
+	}
+	d.Done("a")
+	if state := d.Begin("a"); state != participant.DedupeDone {
+		t.Fatalf("state = %v, want done", state)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	// expired invocation is new again
+	if state := d.Begin("a"); state != participant.DedupeNew {
+		t.Fatalf("state = %v, want new after expiry", state)
+	}
+	if state := d.Begin("a"); state != participant.DedupeInProgress {
+		t.Fatalf("state = %v, want in progress", state)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := participant.New(participant.Config{Secret: "secret"})
+	h.Complete("charge", func(ctx context.Context, inv *participant.Invocation) error {
+		return nil
+	})
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	coord := participanttest.NewCoordinator(srv.URL)
+
+	// not signed
+	if res, _ := coord.Complete("charge", nil); res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unsigned: status code = %v, want 401", res.StatusCode)
+	}
+
+	coord.Secret = "other"
+	if res, _ := coord.Complete("charge", nil); res.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("wrong secret: status code = %v, want 401", res.StatusCode)
+	}
+
+	coord.Secret = "secret"
+	if res, err := coord.Complete("charge", nil); err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("signed: status code = %v, err = %v", res.StatusCode, err)
+	}
+
+	if res, _ := coord.Compensate("charge", nil); res.StatusCode != http.StatusNotFound {
+		t.Fatalf("no handler: status code = %v, want 404", res.StatusCode)
+	}
+
+	resp, err := http.Post(srv.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("not an action: status code = %v, want 400", resp.StatusCode)
+	}
+}
+
+func TestHandlerFiber(t *testing.T) {
+	h := participant.New(participant.Config{})
+
+	var sessionId string
+	h.Prepare("", func(ctx context.Context, inv *participant.Invocation) error {
+		sessionId = inv.SessionId
+		return nil
+	})
+
+	app := fiber.New()
+	app.Use(h.Fiber())
+	app.Get("/health", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	coord := participanttest.NewCoordinator("http://participant/actions")
+	action := coord.Action("", nil)
+	req, err := http.NewRequest(action.GetMethod(), *action.Uri, nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	for name, value := range coord.Session.GetPartActionHeaders(coord.Part, schema.ActionPrepare) {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || sessionId != coord.Session.Id {
+		t.Fatalf("prepare: status code = %v, session = %v", resp.StatusCode, sessionId)
+	}
+
+	// other requests are passed to the next handler
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/health", nil))
+	if err != nil {
+		t.Fatalf("health: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("health: status code = %v, want 200", resp.StatusCode)
+	}
+}
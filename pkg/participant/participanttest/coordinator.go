@@ -0,0 +1,66 @@
+// Package participanttest simulates the coordinator invoking actions of a participant, for testing handlers of package participant.
+package participanttest
+
+import (
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/google/uuid"
+)
+
+// Coordinator invokes actions like the coordinator does, with the same headers and retries.
+type Coordinator struct {
+	Uri     string
+	Session *schema.Session
+	Part    *schema.Participant
+	// actions are signed by Secret if it is set, like ACTION_SIGNING_SECRET of the coordinator
+	Secret string
+}
+
+// NewCoordinator returns a Coordinator invoking actions of a new participant of a new session at uri
+func NewCoordinator(uri string) *Coordinator {
+	return &Coordinator{
+		Uri: uri,
+		Session: &schema.Session{
+			Id: uuid.NewString(),
+		},
+		Part: &schema.Participant{
+			Id: 1,
+		},
+	}
+}
+
+// Action returns a new action with the given name and data
+func (c *Coordinator) Action(name string, data interface{}) *schema.ParticipantAction {
+	uri := c.Uri
+
+	return &schema.ParticipantAction{
+		Name: name,
+		Data: data,
+		Uri:  &uri,
+	}
+}
+
+// Invoke invokes action as the given kind of the participant, calling it again retries it like the coordinator
+func (c *Coordinator) Invoke(kind schema.ActionKind, action *schema.ParticipantAction) (*schema.PartActionResult, error) {
+	err := action.InvokePartAction(c.Session.GetPartActionHeaders(c.Part, kind), c.Secret)
+
+	return action.Results[len(action.Results)-1], err
+}
+
+// Redeliver invokes the completed action again, eg: the coordinator did not receive the response of it
+func (c *Coordinator) Redeliver(kind schema.ActionKind, action *schema.ParticipantAction) (*schema.PartActionResult, error) {
+	action.Status = schema.PartActionFailed
+
+	return c.Invoke(kind, action)
+}
+
+func (c *Coordinator) Complete(name string, data interface{}) (*schema.PartActionResult, error) {
+	return c.Invoke(schema.ActionComplete, c.Action(name, data))
+}
+
+func (c *Coordinator) Compensate(name string, data interface{}) (*schema.PartActionResult, error) {
+	return c.Invoke(schema.ActionCompensate, c.Action(name, data))
+}
+
+func (c *Coordinator) Prepare(name string, data interface{}) (*schema.PartActionResult, error) {
+	return c.Invoke(schema.ActionPrepare, c.Action(name, data))
+}
@@ -0,0 +1,209 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/barrydevp/transcoorditor/pkg/cluster"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Messages are converted from and to schema by json, since fields of messages are named after json of schema.
+// Fields which are not the same are converted by hand, eg: int64 is a string in protojson
+
+var unmarshalOptions = protojson.UnmarshalOptions{
+	// fields of schema which are not in the messages yet
+	DiscardUnknown: true,
+}
+
+func fromSchema(v interface{}, m proto.Message) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalOptions.Unmarshal(b, m)
+}
+
+func toSchema(m proto.Message, v interface{}) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func NewSession(session *schema.Session) (*Session, error) {
+	m := &Session{}
+	if err := fromSchema(session, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func NewListSessionResponse(sessions []*schema.Session) (*ListSessionResponse, error) {
+	res := &ListSessionResponse{}
+	for _, session := range sessions {
+		m, err := NewSession(session)
+		if err != nil {
+			return nil, err
+		}
+		res.Sessions = append(res.Sessions, m)
+	}
+
+	return res, nil
+}
+
+func NewParticipant(part *schema.Participant) (*Participant, error) {
+	m := &Participant{}
+	if err := fromSchema(part, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// NewEvent converts the event of watch, data of it is the session, participant or lock
+func NewEvent(event *schema.Event) (*Event, error) {
+	m := &Event{
+		Id:        event.Id,
+		Type:      string(event.Type),
+		Key:       event.Key,
+		SessionId: event.SessionId,
+	}
+	if event.CreatedAt != nil {
+		m.CreatedAt = timestamppb.New(*event.CreatedAt)
+	}
+
+	switch data := event.Data.(type) {
+	case *schema.Session:
+		session, err := NewSession(data)
+		if err != nil {
+			return nil, err
+		}
+		m.Data = &Event_Session{Session: session}
+	case *schema.Participant:
+		part, err := NewParticipant(data)
+		if err != nil {
+			return nil, err
+		}
+		m.Data = &Event_Participant{Participant: part}
+	case *schema.LockEntry:
+		lock := &LockEntry{}
+		if err := fromSchema(data, lock); err != nil {
+			return nil, err
+		}
+		m.Data = &Event_Lock{Lock: lock}
+	case nil:
+	default:
+		return nil, fmt.Errorf("unknown data %T of event %v", event.Data, event.Type)
+	}
+
+	return m, nil
+}
+
+// NewNode returns an empty node if node is nil, eg: there is no leader
+func NewNode(node *cluster.Node) *Node {
+	if node == nil {
+		return &Node{}
+	}
+
+	return &Node{
+		Id:       node.ID,
+		Host:     node.Host,
+		Addr:     node.Addr,
+		ApiAddr:  node.ApiAddr,
+		GrpcAddr: node.GrpcAddr,
+	}
+}
+
+func NewClusterRsConf(conf *cluster.ClusterRsConf) *ClusterRsConf {
+	if conf == nil {
+		return &ClusterRsConf{}
+	}
+
+	m := &ClusterRsConf{
+		RsName: conf.RsName,
+	}
+	for _, node := range conf.Nodes {
+		m.Nodes = append(m.Nodes, NewNode(node))
+	}
+	if conf.Leader != nil {
+		m.Leader = NewNode(conf.Leader)
+	}
+	if conf.Current != nil {
+		m.Current = NewNode(conf.Current)
+	}
+
+	return m
+}
+
+// ToSchema returns the options of session, unset fields are the defaults of schema.NewSessionOption
+func (m *SessionOptions) ToSchema() (*schema.SessionOptions, error) {
+	opts := schema.NewSessionOption()
+	if err := toSchema(m, opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+func (m *JoinSessionRequest) ToSchema() *schema.ParticipantJoinBody {
+	return &schema.ParticipantJoinBody{
+		ClientId:       m.ClientId,
+		RequestId:      m.RequestId,
+		LeaseTimeoutMs: int(m.LeaseTimeoutMs),
+	}
+}
+
+func (m *PartialCommitRequest) ToSchema() (*schema.ParticipantCommit, error) {
+	commit := &schema.ParticipantCommit{}
+
+	// participant id is not a json number in protojson
+	actions := proto.Clone(m).(*PartialCommitRequest)
+	actions.ParticipantId = nil
+	if err := toSchema(actions, commit); err != nil {
+		return nil, err
+	}
+	commit.Id = m.ParticipantId
+
+	return commit, nil
+}
+
+func (m *KeepAliveSessionRequest) ToSchema() *schema.SessionKeepAliveBody {
+	return &schema.SessionKeepAliveBody{
+		Timeout: int(m.Timeout),
+	}
+}
+
+func (m *Node) ToCluster() *cluster.Node {
+	return &cluster.Node{
+		ID:       m.Id,
+		Host:     m.Host,
+		Addr:     m.Addr,
+		ApiAddr:  m.ApiAddr,
+		GrpcAddr: m.GrpcAddr,
+	}
+}
+
+func (m *ClusterRsConf) ToCluster() *cluster.ClusterRsConf {
+	conf := &cluster.ClusterRsConf{
+		RsName: m.RsName,
+	}
+	for _, node := range m.Nodes {
+		conf.Nodes = append(conf.Nodes, node.ToCluster())
+	}
+	if m.Leader != nil {
+		conf.Leader = m.Leader.ToCluster()
+	}
+	if m.Current != nil {
+		conf.Current = m.Current.ToCluster()
+	}
+
+	return conf
+}
@@ -0,0 +1,903 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: pkg/rpc/coordinator.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CoordinatorClient is the client API for Coordinator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CoordinatorClient interface {
+	StartSession(ctx context.Context, in *SessionOptions, opts ...grpc.CallOption) (*Session, error)
+	GetSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error)
+	ListSession(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionResponse, error)
+	JoinSession(ctx context.Context, in *JoinSessionRequest, opts ...grpc.CallOption) (*Participant, error)
+	PartialCommit(ctx context.Context, in *PartialCommitRequest, opts ...grpc.CallOption) (*Participant, error)
+	HeartbeatParticipant(ctx context.Context, in *HeartbeatParticipantRequest, opts ...grpc.CallOption) (*Participant, error)
+	KeepAliveSession(ctx context.Context, in *KeepAliveSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	CommitSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error)
+	AbortSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error)
+	ForgetSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error)
+	// streams state transitions of the session and its participants until the client is gone,
+	// the client should re-read the session and watch again after the stream ended.
+	// Expiry of the session locks is only streamed by the leader
+	WatchSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (Coordinator_WatchSessionClient, error)
+	// streams state transitions of all sessions and participants, see WatchSession
+	Watch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Coordinator_WatchClient, error)
+	Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type coordinatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCoordinatorClient(cc grpc.ClientConnInterface) CoordinatorClient {
+	return &coordinatorClient{cc}
+}
+
+func (c *coordinatorClient) StartSession(ctx context.Context, in *SessionOptions, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/StartSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) GetSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) ListSession(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionResponse, error) {
+	out := new(ListSessionResponse)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/ListSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) JoinSession(ctx context.Context, in *JoinSessionRequest, opts ...grpc.CallOption) (*Participant, error) {
+	out := new(Participant)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/JoinSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) PartialCommit(ctx context.Context, in *PartialCommitRequest, opts ...grpc.CallOption) (*Participant, error) {
+	out := new(Participant)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/PartialCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) HeartbeatParticipant(ctx context.Context, in *HeartbeatParticipantRequest, opts ...grpc.CallOption) (*Participant, error) {
+	out := new(Participant)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/HeartbeatParticipant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) KeepAliveSession(ctx context.Context, in *KeepAliveSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/KeepAliveSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) CommitSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/CommitSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) AbortSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/AbortSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) ForgetSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/ForgetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) WatchSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (Coordinator_WatchSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Coordinator_ServiceDesc.Streams[0], "/transcoorditor.Coordinator/WatchSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &coordinatorWatchSessionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Coordinator_WatchSessionClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type coordinatorWatchSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *coordinatorWatchSessionClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *coordinatorClient) Watch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Coordinator_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Coordinator_ServiceDesc.Streams[1], "/transcoorditor.Coordinator/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &coordinatorWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Coordinator_WatchClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type coordinatorWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *coordinatorWatchClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *coordinatorClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/transcoorditor.Coordinator/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CoordinatorServer is the server API for Coordinator service.
+// All implementations must embed UnimplementedCoordinatorServer
+// for forward compatibility
+type CoordinatorServer interface {
+	StartSession(context.Context, *SessionOptions) (*Session, error)
+	GetSession(context.Context, *SessionRequest) (*Session, error)
+	ListSession(context.Context, *emptypb.Empty) (*ListSessionResponse, error)
+	JoinSession(context.Context, *JoinSessionRequest) (*Participant, error)
+	PartialCommit(context.Context, *PartialCommitRequest) (*Participant, error)
+	HeartbeatParticipant(context.Context, *HeartbeatParticipantRequest) (*Participant, error)
+	KeepAliveSession(context.Context, *KeepAliveSessionRequest) (*Session, error)
+	CommitSession(context.Context, *SessionRequest) (*Session, error)
+	AbortSession(context.Context, *SessionRequest) (*Session, error)
+	ForgetSession(context.Context, *SessionRequest) (*Session, error)
+	// streams state transitions of the session and its participants until the client is gone,
+	// the client should re-read the session and watch again after the stream ended.
+	// Expiry of the session locks is only streamed by the leader
+	WatchSession(*SessionRequest, Coordinator_WatchSessionServer) error
+	// streams state transitions of all sessions and participants, see WatchSession
+	Watch(*emptypb.Empty, Coordinator_WatchServer) error
+	Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	mustEmbedUnimplementedCoordinatorServer()
+}
+
+// UnimplementedCoordinatorServer must be embedded to have forward compatible implementations.
+type UnimplementedCoordinatorServer struct {
+}
+
+func (UnimplementedCoordinatorServer) StartSession(context.Context, *SessionOptions) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSession not implemented")
+}
+func (UnimplementedCoordinatorServer) GetSession(context.Context, *SessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedCoordinatorServer) ListSession(context.Context, *emptypb.Empty) (*ListSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSession not implemented")
+}
+func (UnimplementedCoordinatorServer) JoinSession(context.Context, *JoinSessionRequest) (*Participant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinSession not implemented")
+}
+func (UnimplementedCoordinatorServer) PartialCommit(context.Context, *PartialCommitRequest) (*Participant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartialCommit not implemented")
+}
+func (UnimplementedCoordinatorServer) HeartbeatParticipant(context.Context, *HeartbeatParticipantRequest) (*Participant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HeartbeatParticipant not implemented")
+}
+func (UnimplementedCoordinatorServer) KeepAliveSession(context.Context, *KeepAliveSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeepAliveSession not implemented")
+}
+func (UnimplementedCoordinatorServer) CommitSession(context.Context, *SessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitSession not implemented")
+}
+func (UnimplementedCoordinatorServer) AbortSession(context.Context, *SessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortSession not implemented")
+}
+func (UnimplementedCoordinatorServer) ForgetSession(context.Context, *SessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForgetSession not implemented")
+}
+func (UnimplementedCoordinatorServer) WatchSession(*SessionRequest, Coordinator_WatchSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSession not implemented")
+}
+func (UnimplementedCoordinatorServer) Watch(*emptypb.Empty, Coordinator_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedCoordinatorServer) Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedCoordinatorServer) mustEmbedUnimplementedCoordinatorServer() {}
+
+// UnsafeCoordinatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CoordinatorServer will
+// result in compilation errors.
+type UnsafeCoordinatorServer interface {
+	mustEmbedUnimplementedCoordinatorServer()
+}
+
+func RegisterCoordinatorServer(s grpc.ServiceRegistrar, srv CoordinatorServer) {
+	s.RegisterService(&Coordinator_ServiceDesc, srv)
+}
+
+func _Coordinator_StartSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).StartSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/StartSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).StartSession(ctx, req.(*SessionOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).GetSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_ListSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).ListSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/ListSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).ListSession(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_JoinSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).JoinSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/JoinSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).JoinSession(ctx, req.(*JoinSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_PartialCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartialCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).PartialCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/PartialCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).PartialCommit(ctx, req.(*PartialCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_HeartbeatParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).HeartbeatParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/HeartbeatParticipant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).HeartbeatParticipant(ctx, req.(*HeartbeatParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_KeepAliveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeepAliveSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).KeepAliveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/KeepAliveSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).KeepAliveSession(ctx, req.(*KeepAliveSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_CommitSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).CommitSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/CommitSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).CommitSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_AbortSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).AbortSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/AbortSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).AbortSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_ForgetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).ForgetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/ForgetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).ForgetSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_WatchSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SessionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoordinatorServer).WatchSession(m, &coordinatorWatchSessionServer{stream})
+}
+
+type Coordinator_WatchSessionServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type coordinatorWatchSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *coordinatorWatchSessionServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Coordinator_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoordinatorServer).Watch(m, &coordinatorWatchServer{stream})
+}
+
+type Coordinator_WatchServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type coordinatorWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *coordinatorWatchServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Coordinator_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.Coordinator/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).Ping(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Coordinator_ServiceDesc is the grpc.ServiceDesc for Coordinator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Coordinator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "transcoorditor.Coordinator",
+	HandlerType: (*CoordinatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartSession",
+			Handler:    _Coordinator_StartSession_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _Coordinator_GetSession_Handler,
+		},
+		{
+			MethodName: "ListSession",
+			Handler:    _Coordinator_ListSession_Handler,
+		},
+		{
+			MethodName: "JoinSession",
+			Handler:    _Coordinator_JoinSession_Handler,
+		},
+		{
+			MethodName: "PartialCommit",
+			Handler:    _Coordinator_PartialCommit_Handler,
+		},
+		{
+			MethodName: "HeartbeatParticipant",
+			Handler:    _Coordinator_HeartbeatParticipant_Handler,
+		},
+		{
+			MethodName: "KeepAliveSession",
+			Handler:    _Coordinator_KeepAliveSession_Handler,
+		},
+		{
+			MethodName: "CommitSession",
+			Handler:    _Coordinator_CommitSession_Handler,
+		},
+		{
+			MethodName: "AbortSession",
+			Handler:    _Coordinator_AbortSession_Handler,
+		},
+		{
+			MethodName: "ForgetSession",
+			Handler:    _Coordinator_ForgetSession_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _Coordinator_Ping_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSession",
+			Handler:       _Coordinator_WatchSession_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Coordinator_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/rpc/coordinator.proto",
+}
+
+// SystemClient is the client API for System service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SystemClient interface {
+	InitiateCluster(ctx context.Context, in *ClusterRsConf, opts ...grpc.CallOption) (*ClusterRsConf, error)
+	JoinCluster(ctx context.Context, in *Node, opts ...grpc.CallOption) (*ClusterRsConf, error)
+	LeftCluster(ctx context.Context, in *Node, opts ...grpc.CallOption) (*ClusterRsConf, error)
+	GetClusterRsConf(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterRsConf, error)
+	GetClusterStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterStatsResponse, error)
+	GetClusterLeader(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Node, error)
+	GetClusterCurrent(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Node, error)
+}
+
+type systemClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSystemClient(cc grpc.ClientConnInterface) SystemClient {
+	return &systemClient{cc}
+}
+
+func (c *systemClient) InitiateCluster(ctx context.Context, in *ClusterRsConf, opts ...grpc.CallOption) (*ClusterRsConf, error) {
+	out := new(ClusterRsConf)
+	err := c.cc.Invoke(ctx, "/transcoorditor.System/InitiateCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) JoinCluster(ctx context.Context, in *Node, opts ...grpc.CallOption) (*ClusterRsConf, error) {
+	out := new(ClusterRsConf)
+	err := c.cc.Invoke(ctx, "/transcoorditor.System/JoinCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) LeftCluster(ctx context.Context, in *Node, opts ...grpc.CallOption) (*ClusterRsConf, error) {
+	out := new(ClusterRsConf)
+	err := c.cc.Invoke(ctx, "/transcoorditor.System/LeftCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetClusterRsConf(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterRsConf, error) {
+	out := new(ClusterRsConf)
+	err := c.cc.Invoke(ctx, "/transcoorditor.System/GetClusterRsConf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetClusterStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterStatsResponse, error) {
+	out := new(ClusterStatsResponse)
+	err := c.cc.Invoke(ctx, "/transcoorditor.System/GetClusterStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetClusterLeader(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Node, error) {
+	out := new(Node)
+	err := c.cc.Invoke(ctx, "/transcoorditor.System/GetClusterLeader", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetClusterCurrent(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Node, error) {
+	out := new(Node)
+	err := c.cc.Invoke(ctx, "/transcoorditor.System/GetClusterCurrent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SystemServer is the server API for System service.
+// All implementations must embed UnimplementedSystemServer
+// for forward compatibility
+type SystemServer interface {
+	InitiateCluster(context.Context, *ClusterRsConf) (*ClusterRsConf, error)
+	JoinCluster(context.Context, *Node) (*ClusterRsConf, error)
+	LeftCluster(context.Context, *Node) (*ClusterRsConf, error)
+	GetClusterRsConf(context.Context, *emptypb.Empty) (*ClusterRsConf, error)
+	GetClusterStats(context.Context, *emptypb.Empty) (*ClusterStatsResponse, error)
+	GetClusterLeader(context.Context, *emptypb.Empty) (*Node, error)
+	GetClusterCurrent(context.Context, *emptypb.Empty) (*Node, error)
+	mustEmbedUnimplementedSystemServer()
+}
+
+// UnimplementedSystemServer must be embedded to have forward compatible implementations.
+type UnimplementedSystemServer struct {
+}
+
+func (UnimplementedSystemServer) InitiateCluster(context.Context, *ClusterRsConf) (*ClusterRsConf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitiateCluster not implemented")
+}
+func (UnimplementedSystemServer) JoinCluster(context.Context, *Node) (*ClusterRsConf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinCluster not implemented")
+}
+func (UnimplementedSystemServer) LeftCluster(context.Context, *Node) (*ClusterRsConf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeftCluster not implemented")
+}
+func (UnimplementedSystemServer) GetClusterRsConf(context.Context, *emptypb.Empty) (*ClusterRsConf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterRsConf not implemented")
+}
+func (UnimplementedSystemServer) GetClusterStats(context.Context, *emptypb.Empty) (*ClusterStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterStats not implemented")
+}
+func (UnimplementedSystemServer) GetClusterLeader(context.Context, *emptypb.Empty) (*Node, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterLeader not implemented")
+}
+func (UnimplementedSystemServer) GetClusterCurrent(context.Context, *emptypb.Empty) (*Node, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterCurrent not implemented")
+}
+func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
+
+// UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SystemServer will
+// result in compilation errors.
+type UnsafeSystemServer interface {
+	mustEmbedUnimplementedSystemServer()
+}
+
+func RegisterSystemServer(s grpc.ServiceRegistrar, srv SystemServer) {
+	s.RegisterService(&System_ServiceDesc, srv)
+}
+
+func _System_InitiateCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterRsConf)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).InitiateCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.System/InitiateCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).InitiateCluster(ctx, req.(*ClusterRsConf))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_JoinCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Node)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).JoinCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.System/JoinCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).JoinCluster(ctx, req.(*Node))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_LeftCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Node)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).LeftCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.System/LeftCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).LeftCluster(ctx, req.(*Node))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetClusterRsConf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetClusterRsConf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.System/GetClusterRsConf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetClusterRsConf(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetClusterStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetClusterStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.System/GetClusterStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetClusterStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetClusterLeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetClusterLeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.System/GetClusterLeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetClusterLeader(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetClusterCurrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetClusterCurrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transcoorditor.System/GetClusterCurrent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetClusterCurrent(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// System_ServiceDesc is the grpc.ServiceDesc for System service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var System_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "transcoorditor.System",
+	HandlerType: (*SystemServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InitiateCluster",
+			Handler:    _System_InitiateCluster_Handler,
+		},
+		{
+			MethodName: "JoinCluster",
+			Handler:    _System_JoinCluster_Handler,
+		},
+		{
+			MethodName: "LeftCluster",
+			Handler:    _System_LeftCluster_Handler,
+		},
+		{
+			MethodName: "GetClusterRsConf",
+			Handler:    _System_GetClusterRsConf_Handler,
+		},
+		{
+			MethodName: "GetClusterStats",
+			Handler:    _System_GetClusterStats_Handler,
+		},
+		{
+			MethodName: "GetClusterLeader",
+			Handler:    _System_GetClusterLeader_Handler,
+		},
+		{
+			MethodName: "GetClusterCurrent",
+			Handler:    _System_GetClusterCurrent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/rpc/coordinator.proto",
+}
@@ -0,0 +1,223 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/rpc"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// coordinator implements a few methods, the others are unimplemented
+type coordinator struct {
+	rpc.UnimplementedCoordinatorServer
+}
+
+func (c *coordinator) StartSession(ctx context.Context, req *rpc.SessionOptions) (*rpc.Session, error) {
+	opts, err := req.ToSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.NewSession(schema.NewSession(opts))
+}
+
+func (c *coordinator) GetSession(ctx context.Context, req *rpc.SessionRequest) (*rpc.Session, error) {
+	return nil, exception.AppNotFoundf("session %v was not found", req.SessionId)
+}
+
+func (c *coordinator) CommitSession(ctx context.Context, req *rpc.SessionRequest) (*rpc.Session, error) {
+	return nil, errors.New("store is down")
+}
+
+func (c *coordinator) WatchSession(req *rpc.SessionRequest, stream rpc.Coordinator_WatchSessionServer) error {
+	part := schema.NewParticipant()
+	part.SessionId = req.SessionId
+	part.Id = 1
+
+	event, err := rpc.NewEvent(schema.NewEvent(schema.EventParticipantStateChanged, "1", req.SessionId, part))
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(event); err != nil {
+		return err
+	}
+
+	return exception.AppServiceUnavailable(errors.New("watch is closed"))
+}
+
+func newClient(t *testing.T) rpc.CoordinatorClient {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(rpc.StatusInterceptor),
+		grpc.StreamInterceptor(rpc.StreamStatusInterceptor),
+	)
+	rpc.RegisterCoordinatorServer(srv, &coordinator{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return rpc.NewCoordinatorClient(conn)
+}
+
+func TestCoordinatorClient(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	key := "key"
+	session, err := client.StartSession(ctx, &rpc.SessionOptions{Timeout: 60, LockKey: &key, Mode: string(schema.SessionTCC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Id == "" || session.Timeout != 60 || session.LockKey == nil || *session.LockKey != key || session.Mode != string(schema.SessionTCC) {
+		t.Errorf("unexpected session %+v", session)
+	}
+	// unset options are the defaults
+	if session.Concurrency != int32(schema.NewSessionOption().Concurrency) || session.RetryPolicy == nil {
+		t.Errorf("expected default options, got %+v", session)
+	}
+
+	_, err = client.GetSession(ctx, &rpc.SessionRequest{SessionId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	_, err = client.CommitSession(ctx, &rpc.SessionRequest{SessionId: "id"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal, got %v", err)
+	}
+
+	_, err = client.ForgetSession(ctx, &rpc.SessionRequest{SessionId: "id"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented, got %v", err)
+	}
+}
+
+func TestCoordinatorClientWatch(t *testing.T) {
+	client := newClient(t)
+
+	stream, err := client.WatchSession(context.Background(), &rpc.SessionRequest{SessionId: "id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != string(schema.EventParticipantStateChanged) || event.SessionId != "id" || event.GetParticipant().GetId() != 1 {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	// error of streaming handler is converted too
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	now := time.Now()
+	uri := "http://participant/refund"
+
+	session := schema.NewSession(schema.NewSessionOption())
+	session.StartedAt = &now
+	session.LockToken = 42
+	part := schema.NewParticipant()
+	part.Id = 7
+	part.SessionId = session.Id
+	part.CompensateAction = &schema.ParticipantAction{
+		Uri:     &uri,
+		Data:    map[string]interface{}{"orderId": "o-1"},
+		Headers: map[string]string{"X-Tenant": "t"},
+		Results: []*schema.PartActionResult{{StatusCode: 500}},
+	}
+	session.Participants = []*schema.Participant{part}
+
+	m, err := rpc.NewSession(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Id != session.Id || m.State != string(session.State) || !m.StartedAt.AsTime().Equal(now) || m.LockToken != 42 {
+		t.Errorf("unexpected session %+v", m)
+	}
+	if len(m.Participants) != 1 || m.Participants[0].Id != 7 {
+		t.Fatalf("unexpected participants %+v", m.Participants)
+	}
+	action := m.Participants[0].CompensateAction
+	if action.Uri != uri || action.Data.GetStructValue().AsMap()["orderId"] != "o-1" || action.Headers["X-Tenant"] != "t" || action.Results[0].StatusCode != 500 {
+		t.Errorf("unexpected action %+v", action)
+	}
+
+	lock := schema.NewLockEntry("key", session.Id, time.Minute)
+	event, err := rpc.NewEvent(schema.NewEvent(schema.EventLockExpired, lock.Key, session.Id, lock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.GetLock().GetKey() != "key" || event.GetLock().GetOwner() != session.Id || !event.GetLock().GetExpiredAt().AsTime().Equal(*lock.ExpiredAt) {
+		t.Errorf("unexpected lock event %+v", event)
+	}
+
+	id := int64(7)
+	commit, err := (&rpc.PartialCommitRequest{
+		SessionId:            session.Id,
+		ParticipantId:        &id,
+		Compensate:           action,
+		ReservationTimeoutMs: 1000,
+	}).ToSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Id == nil || *commit.Id != 7 || commit.ReservationTimeoutMs != 1000 {
+		t.Errorf("unexpected commit %+v", commit)
+	}
+	if commit.Compensate == nil || *commit.Compensate.Uri != uri || commit.Compensate.Data.(map[string]interface{})["orderId"] != "o-1" {
+		t.Errorf("unexpected compensate action %+v", commit.Compensate)
+	}
+}
+
+func TestCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{exception.AppBadRequest(errors.New("bad")), codes.InvalidArgument},
+		{exception.AppNotFoundf("missing"), codes.NotFound},
+		{exception.AppConflict(errors.New("lock exists")), codes.Aborted},
+		{exception.AppGonef("expired"), codes.NotFound},
+		{exception.AppPreconditionFailedf("not active"), codes.FailedPrecondition},
+		{exception.AppUnprocessableEntityf("not yet"), codes.FailedPrecondition},
+		{exception.AppServiceUnavailable(errors.New("not leader")), codes.Unavailable},
+		{exception.AppTimeout(errors.New("timeout")), codes.DeadlineExceeded},
+		{exception.AppInternalError(errors.New("internal")), codes.Internal},
+		{errors.New("unknown"), codes.Internal},
+		// wrapped app error
+		{exception.Errorf("wrapped: %w", exception.AppNotFoundf("missing")), codes.NotFound},
+	}
+
+	for _, c := range cases {
+		if code := rpc.Code(c.err); code != c.code {
+			t.Errorf("expected %v of %v, got %v", c.code, c.err, code)
+		}
+	}
+}
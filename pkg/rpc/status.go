@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LeaderMetadataKey is set in trailer of the response of follower with grpc address of the leader,
+// client should retry write requests on it
+const LeaderMetadataKey = "x-transcoorditor-leader"
+
+// statusCodes maps http status of exception.AppError to grpc code
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusGone:                codes.NotFound,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// Code returns grpc code of the error, error which is not an exception.AppError is an internal error
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	var apiErr *exception.AppError
+	if !errors.As(err, &apiErr) {
+		return codes.Internal
+	}
+
+	if code, ok := statusCodes[apiErr.Status()]; ok {
+		return code
+	}
+
+	return codes.Internal
+}
+
+// Status converts the error to grpc status error, the message is the same as "msg: err" of http api
+func Status(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	msg := err.Error()
+
+	var apiErr *exception.AppError
+	if errors.As(err, &apiErr) && apiErr.Msg != "" && apiErr.Err() != nil {
+		msg = fmt.Sprintf("%v: %v", apiErr.Msg, apiErr.Err())
+	}
+
+	return status.Error(Code(err), msg)
+}
+
+// StatusInterceptor converts errors returned by handlers to grpc status errors
+func StatusInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, Status(err)
+	}
+
+	return res, nil
+}
+
+// StreamStatusInterceptor is StatusInterceptor of streaming handlers
+func StreamStatusInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return Status(handler(srv, ss))
+}
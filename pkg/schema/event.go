@@ -1 +1,44 @@
 package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	// a lock held by an unfinished session has expired, the session might lose its isolation.
+	// key is the lock key and data is the lock entry
+	EventLockExpired EventType = "LockExpired"
+	// session has moved to a new state, data is the session
+	EventSessionStateChanged EventType = "SessionStateChanged"
+	// data is the deleted session
+	EventSessionDeleted EventType = "SessionDeleted"
+	// participant has moved to a new state, key is the participant id and data is the participant
+	EventParticipantStateChanged EventType = "ParticipantStateChanged"
+)
+
+type Event struct {
+	Id   string    `json:"id"`
+	Type EventType `json:"type"`
+	// key of the resource which the event is about, eg: lock key
+	Key       string      `json:"key,omitempty"`
+	SessionId string      `json:"sessionId,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	CreatedAt *time.Time  `json:"createdAt"`
+}
+
+func NewEvent(eventType EventType, key string, sessionId string, data interface{}) *Event {
+	now := time.Now()
+
+	return &Event{
+		Id:        uuid.NewString(),
+		Type:      eventType,
+		Key:       key,
+		SessionId: sessionId,
+		Data:      data,
+		CreatedAt: &now,
+	}
+}
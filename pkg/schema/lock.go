@@ -1,15 +1,45 @@
 package schema
 
 import (
+	"sort"
 	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/common"
 	// "github.com/google/uuid"
 )
 
+type LockMode string
+
+const (
+	// only one owner holds the lock
+	LockExclusive LockMode = "exclusive"
+	// many owners hold the lock at the same time, eg: readers
+	LockShared LockMode = "shared"
+)
+
+type LockHolder struct {
+	Owner     string     `json:"owner" bson:"owner"`
+	ExpiredAt *time.Time `json:"expiredAt" bson:"expiredAt"`
+}
+
+func (h *LockHolder) IsExpired() bool {
+	return h.ExpiredAt.Before(time.Now())
+}
+
 type LockEntry struct {
-	Key   string `json:"key" bson:"key"`
+	Key string `json:"key" bson:"key"`
+	// owner of exclusive lock, it is empty for shared lock
 	Owner string `json:"owner" bson:"owner"`
+	// empty mode is exclusive, for entries were saved before shared lock
+	Mode LockMode `json:"mode,omitempty" bson:"mode,omitempty"`
+	// owners of shared lock
+	Holders []*LockHolder `json:"holders,omitempty" bson:"holders,omitempty"`
 	// Uid       string     `json:"uid" bson:"uid"`
+	// expiredAt of shared lock is the latest expiredAt of its holders
 	ExpiredAt *time.Time `json:"expiredAt" bson:"expiredAt"`
+	// fencing token, it is increased every time the lock is acquired.
+	// it is the index of raft log which saved the lock entry
+	Token uint64 `json:"token" bson:"token"`
 }
 
 func NewLockEntry(key string, owner string, duration time.Duration) *LockEntry {
@@ -18,15 +48,202 @@ func NewLockEntry(key string, owner string, duration time.Duration) *LockEntry {
 	return &LockEntry{
 		Key:       key,
 		Owner:     owner,
+		Mode:      LockExclusive,
 		ExpiredAt: &expiredAt,
 		// Uid:       uuid.NewString(),
 	}
 }
 
+func NewSharedLockEntry(key string, owner string, duration time.Duration) *LockEntry {
+	lockEnt := &LockEntry{
+		Key:  key,
+		Mode: LockShared,
+	}
+	lockEnt.AddHolder(owner, duration)
+
+	return lockEnt
+}
+
+func NewLockEntryWithMode(key string, owner string, mode LockMode, duration time.Duration) *LockEntry {
+	if mode == LockShared {
+		return NewSharedLockEntry(key, owner, duration)
+	}
+
+	return NewLockEntry(key, owner, duration)
+}
+
+// SortLockKeys returns sorted and unique keys, locks are always acquired in this order to avoid deadlock
+func SortLockKeys(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	sorted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func (l *LockEntry) IsShared() bool {
+	return l.Mode == LockShared
+}
+
 func (l *LockEntry) IsExpired() bool {
 	return l.ExpiredAt.Before(time.Now())
 }
 
 func (l *LockEntry) Extend(duration time.Duration) {
-	l.ExpiredAt.Add(duration)
+	expiredAt := l.ExpiredAt.Add(duration)
+	l.ExpiredAt = &expiredAt
+}
+
+// HasOwner returns true if owner is the owner of exclusive lock or a holder of shared lock
+func (l *LockEntry) HasOwner(owner string) bool {
+	if !l.IsShared() {
+		return l.Owner == owner
+	}
+
+	return l.GetHolder(owner) != nil
+}
+
+func (l *LockEntry) GetHolder(owner string) *LockHolder {
+	for _, h := range l.Holders {
+		if h.Owner == owner {
+			return h
+		}
+	}
+
+	return nil
+}
+
+// AddHolder adds owner to holders of shared lock, the expiredAt is renewed if owner has already held it
+func (l *LockEntry) AddHolder(owner string, duration time.Duration) {
+	expiredAt := time.Now().Add(duration)
+
+	if h := l.GetHolder(owner); h != nil {
+		h.ExpiredAt = &expiredAt
+	} else {
+		l.Holders = append(l.Holders, &LockHolder{
+			Owner:     owner,
+			ExpiredAt: &expiredAt,
+		})
+	}
+
+	l.refreshExpiredAt()
+}
+
+func (l *LockEntry) ExtendHolder(owner string, duration time.Duration) {
+	if h := l.GetHolder(owner); h != nil {
+		expiredAt := h.ExpiredAt.Add(duration)
+		h.ExpiredAt = &expiredAt
+	}
+
+	l.refreshExpiredAt()
+}
+
+func (l *LockEntry) RemoveHolder(owner string) {
+	holders := l.Holders[:0]
+	for _, h := range l.Holders {
+		if h.Owner != owner {
+			holders = append(holders, h)
+		}
+	}
+	l.Holders = holders
+
+	l.refreshExpiredAt()
+}
+
+// ReleaseOwner releases the lock held by owner, it returns false if owner does not hold the lock.
+// Shared lock is still held if it has other holders, see IsHeld
+func (l *LockEntry) ReleaseOwner(owner string) bool {
+	if !l.HasOwner(owner) {
+		return false
+	}
+
+	if l.IsShared() {
+		l.RemoveHolder(owner)
+	} else {
+		l.Owner = ""
+	}
+
+	return true
+}
+
+// IsHeld returns true if exclusive lock has its owner or shared lock has any holder
+func (l *LockEntry) IsHeld() bool {
+	if l.IsShared() {
+		return len(l.Holders) > 0
+	}
+
+	return l.Owner != ""
+}
+
+// PruneHolders removes expired holders of shared lock
+func (l *LockEntry) PruneHolders() {
+	holders := l.Holders[:0]
+	for _, h := range l.Holders {
+		if !h.IsExpired() {
+			holders = append(holders, h)
+		}
+	}
+	l.Holders = holders
+
+	l.refreshExpiredAt()
+}
+
+func (l *LockEntry) refreshExpiredAt() {
+	var expiredAt *time.Time
+	for _, h := range l.Holders {
+		if expiredAt == nil || h.ExpiredAt.After(*expiredAt) {
+			expiredAt = h.ExpiredAt
+		}
+	}
+
+	if expiredAt == nil {
+		now := time.Now()
+		expiredAt = &now
+	}
+
+	t := *expiredAt
+	l.ExpiredAt = &t
+}
+
+type LockAcquireBody struct {
+	Owner string `json:"owner" validate:"required"`
+	TtlMs int    `json:"ttlMs" validate:"required,min=1"`
+	// lock mode, only used by acquire, default is exclusive
+	Mode LockMode `json:"mode" validate:"omitempty,oneof=exclusive shared"`
+	// wait at most this duration in FIFO order if the lock is held, only used by acquire
+	WaitMs int `json:"waitMs" validate:"min=0,max=300000"`
+}
+
+func (b *LockAcquireBody) Validate() error {
+	return common.GetValidate().Struct(b)
+}
+
+func (b *LockAcquireBody) GetTtl() time.Duration {
+	return time.Duration(b.TtlMs) * time.Millisecond
+}
+
+func (b *LockAcquireBody) GetMode() LockMode {
+	if b.Mode == "" {
+		return LockExclusive
+	}
+
+	return b.Mode
+}
+
+func (b *LockAcquireBody) GetWait() time.Duration {
+	return time.Duration(b.WaitMs) * time.Millisecond
+}
+
+type LockReleaseBody struct {
+	Owner string `json:"owner" validate:"required"`
+}
+
+func (b *LockReleaseBody) Validate() error {
+	return common.GetValidate().Struct(b)
 }
@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var (
+	ErrNotifyRequestFailed = errors.New("notify request failed")
+)
+
+const (
+	EventIdHeader = "X-Transcoorditor-Event-Id"
+)
+
+// SessionNotification tracks the delivery of session state to a notification url.
+// Only the latest state is delivered, intermediate states which were passed before their delivery are skipped
+type SessionNotification struct {
+	Url string `json:"url" bson:"url"`
+	// the last state which was delivered to Url
+	DeliveredState SessionState `json:"deliveredState,omitempty" bson:"deliveredState,omitempty"`
+	DeliveredAt    *time.Time   `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+	// the state which is being delivered, Attempts are counted for it
+	State         SessionState `json:"state,omitempty" bson:"state,omitempty"`
+	Attempts      int          `json:"attempts,omitempty" bson:"attempts,omitempty"`
+	NextAttemptAt *time.Time   `json:"nextAttemptAt,omitempty" bson:"nextAttemptAt,omitempty"`
+	// delivery of State was given up after the maximum attempts
+	GaveUp    bool   `json:"gaveUp,omitempty" bson:"gaveUp,omitempty"`
+	LastError string `json:"lastError,omitempty" bson:"lastError,omitempty"`
+}
+
+func newSessionNotifications(urls []string) []*SessionNotification {
+	var notifications []*SessionNotification
+
+	for _, url := range urls {
+		notifications = append(notifications, &SessionNotification{Url: url})
+	}
+
+	return notifications
+}
+
+// IsPending reports whether state has not been delivered nor given up yet
+func (n *SessionNotification) IsPending(state SessionState) bool {
+	if state == "" || state == n.DeliveredState {
+		return false
+	}
+
+	return !(n.State == state && n.GaveUp)
+}
+
+// DueAt returns when state should be delivered, it is now for the new state
+func (n *SessionNotification) DueAt(state SessionState, now time.Time) time.Time {
+	if n.State == state && n.NextAttemptAt != nil {
+		return *n.NextAttemptAt
+	}
+
+	return now
+}
+
+// Attempt records an attempt of delivering state, the attempts are reset if the state was changed
+func (n *SessionNotification) Attempt(state SessionState) {
+	if n.State != state {
+		n.State = state
+		n.Attempts = 0
+		n.GaveUp = false
+	}
+
+	n.Attempts++
+	n.NextAttemptAt = nil
+	n.LastError = ""
+}
+
+func (n *SessionNotification) Delivered(state SessionState) {
+	now := time.Now()
+
+	n.DeliveredState = state
+	n.DeliveredAt = &now
+}
+
+// Failed records the error of the last attempt, state is given up if nextAttemptAt is nil
+func (n *SessionNotification) Failed(err error, nextAttemptAt *time.Time) {
+	n.LastError = err.Error()
+	n.NextAttemptAt = nextAttemptAt
+	n.GaveUp = nextAttemptAt == nil
+}
+
+// Notify posts event to Url, it is signed like participant actions if secret is not empty
+func (n *SessionNotification) Notify(event *Event, timeout time.Duration, secret string) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req := resty.New().SetTimeout(timeout).R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader(SessionIdHeader, event.SessionId).
+		SetHeader(EventIdHeader, event.Id).
+		SetBody(body)
+
+	signRequest(req, secret, event.Id, body)
+
+	resp, err := req.Post(n.Url)
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("%w: unexpected status code %v", ErrNotifyRequestFailed, resp.StatusCode())
+	}
+
+	return nil
+}
+
+// NewSessionStateEvent returns the event of session state, its id is the same for every delivery of the state
+func NewSessionStateEvent(session *Session) *Event {
+	event := NewEvent(EventSessionStateChanged, "", session.Id, session)
+	event.Id = fmt.Sprintf("%v/%v", session.Id, session.State)
+
+	return event
+}
+
+func IsFinalSessionState(state SessionState) bool {
+	switch state {
+	case SessionCommitted, SessionAborted, SessionTerminated:
+		return true
+	default:
+		return false
+	}
+}
+
+// NotifyState returns the state which should be delivered to notification urls, empty if there is nothing to deliver
+func (s *Session) NotifyState() SessionState {
+	if len(s.Notifications) == 0 {
+		return ""
+	}
+
+	if IsFinalSessionState(s.State) || s.NotifyIntermediate {
+		return s.State
+	}
+
+	return ""
+}
+
+// PendingNotifications returns notifications of urls which NotifyState has not been delivered to
+func (s *Session) PendingNotifications() []*SessionNotification {
+	var pending []*SessionNotification
+
+	state := s.NotifyState()
+	for _, n := range s.Notifications {
+		if n.IsPending(state) {
+			pending = append(pending, n)
+		}
+	}
+
+	return pending
+}
+
+// NextNotifyAt returns when the earliest pending notification is due, nil if there is no pending notification
+func (s *Session) NextNotifyAt() *time.Time {
+	var next *time.Time
+
+	now := time.Now()
+	state := s.NotifyState()
+	for _, n := range s.PendingNotifications() {
+		if dueAt := n.DueAt(state, now); next == nil || dueAt.Before(*next) {
+			next = &dueAt
+		}
+	}
+
+	return next
+}
+
+func (s *Session) IsNotifying() bool {
+	return len(s.PendingNotifications()) > 0
+}
@@ -1,9 +1,17 @@
 package schema
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/barrydevp/transcoorditor/pkg/common"
@@ -14,10 +22,75 @@ import (
 )
 
 var (
-	ErrActionRequestFailed = errors.New("action request failed")
-	ErrInvalidActionUri    = fmt.Errorf("invalid action's uri. %w", exception.ErrInvalidArgument)
+	ErrActionRequestFailed     = errors.New("action request failed")
+	ErrInvalidActionUri        = fmt.Errorf("invalid action's uri. %w", exception.ErrInvalidArgument)
+	ErrInvalidActionMethod     = fmt.Errorf("invalid action's method. %w", exception.ErrInvalidArgument)
+	ErrInvalidActionHeader     = fmt.Errorf("invalid action's header. %w", exception.ErrInvalidArgument)
+	ErrInvalidActionTimeout    = fmt.Errorf("invalid action's timeoutMs. %w", exception.ErrInvalidArgument)
+	ErrInvalidActionStatusCode = fmt.Errorf("invalid action's successStatusCodes. %w", exception.ErrInvalidArgument)
 )
 
+const (
+	defaultActionMethod = http.MethodPost
+
+	// resource servers should reject the action which has stale fencing token
+	FencingTokenHeader = "X-Transcoorditor-Fencing-Token"
+
+	// identify the invoked action, see Session.GetPartActionHeaders
+	SessionIdHeader     = "X-Transcoorditor-Session-Id"
+	ParticipantIdHeader = "X-Transcoorditor-Participant-Id"
+	ActionKindHeader    = "X-Transcoorditor-Action"
+	ActionNameHeader    = "X-Transcoorditor-Action-Name"
+	// the same on every retry of the action, participants dedupe retries by it
+	InvocationIdHeader = "X-Transcoorditor-Invocation-Id"
+	AttemptHeader      = "X-Transcoorditor-Attempt"
+
+	// set when the request is signed by a secret, see SignAction
+	TimestampHeader = "X-Transcoorditor-Timestamp"
+	SignatureHeader = "X-Transcoorditor-Signature"
+)
+
+type ActionKind string
+
+const (
+	ActionComplete   ActionKind = "complete"
+	ActionCompensate ActionKind = "compensate"
+	ActionPrepare    ActionKind = "prepare"
+)
+
+// InvocationId returns the id of the action of participant, it does not change between retries
+func InvocationId(sessionId string, participantId int64, kind ActionKind) string {
+	return fmt.Sprintf("%v/%v/%v", sessionId, participantId, kind)
+}
+
+// SignAction returns hex encoded HMAC-SHA256 of "timestamp.invocationId.body" by secret
+func SignAction(secret string, timestamp string, invocationId string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + invocationId + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signRequest sets the signature headers of req if secret is set, id identifies the request, see SignAction
+func signRequest(req *resty.Request, secret string, id string, body []byte) {
+	if secret == "" {
+		return
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.SetHeader(TimestampHeader, timestamp)
+	req.SetHeader(SignatureHeader, SignAction(secret, timestamp, id, body))
+}
+
+var actionMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
 type PartActionStatus string
 
 const (
@@ -26,6 +99,8 @@ const (
 	PartActionCompleted  PartActionStatus = "Completed"
 	PartActionFailed     PartActionStatus = "Failed"
 
+	// action is given up after it was invoked this many times, regardless of session retry policy,
+	// an action is invoked at most once per commit/abort/terminate attempt
 	MAX_ACTION_INVOKED = 10
 )
 
@@ -44,24 +119,35 @@ func (pr *PartActionResult) SetError(err error) {
 }
 
 func (pr *PartActionResult) ParseRestyResp(resp *resty.Response, err error) error {
-	if pr != nil {
+	// transport error (eg: timeout, connection refused), there is no response
+	if err != nil {
+		return err
+	}
+
+	if pr != nil && resp != nil {
 		pr.StatusCode = resp.StatusCode()
 		pr.Status = resp.Status()
 		pr.Proto = resp.Proto()
 		pr.Time = resp.Time().Milliseconds()
 		pr.ReceivedAt = resp.ReceivedAt()
 		pr.Body = resp.String()
-		if resp.StatusCode() != 200 {
-			return ErrActionRequestFailed
-		}
 	}
 
-	return err
+	return nil
 }
 
 type ParticipantAction struct {
-	Data         interface{}         `json:"data" bson:"data"`
-	Uri          *string             `json:"uri" bson:"uri" validate:"required"`
+	// name of the action handler of participant, it is sent in ActionNameHeader
+	Name    string            `json:"name,omitempty" bson:"name,omitempty"`
+	Data    interface{}       `json:"data" bson:"data"`
+	Uri     *string           `json:"uri" bson:"uri" validate:"required"`
+	Method  string            `json:"method,omitempty" bson:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty" bson:"headers,omitempty"`
+	// request timeout, no timeout if it is 0
+	TimeoutMs int `json:"timeoutMs,omitempty" bson:"timeoutMs,omitempty"`
+	// any 2xx status code is success if it is empty
+	SuccessStatusCodes []int `json:"successStatusCodes,omitempty" bson:"successStatusCodes,omitempty"`
+
 	Status       PartActionStatus    `json:"status" bson:"status"`
 	Results      []*PartActionResult `json:"results" bson:"results"`
 	InvokedCount int                 `json:"invokedCount" bson:"invokedCount"`
@@ -73,15 +159,74 @@ func (pa *ParticipantAction) IsFinished() bool {
 	return pa.Status == PartActionCompleted || pa.InvokedCount > MAX_ACTION_INVOKED
 }
 
-func (pa *ParticipantAction) requestActionHTTP() (*resty.Response, error) {
+func (pa *ParticipantAction) GetMethod() string {
+	if pa.Method == "" {
+		return defaultActionMethod
+	}
+
+	return strings.ToUpper(pa.Method)
+}
+
+func (pa *ParticipantAction) IsSuccessStatusCode(code int) bool {
+	if len(pa.SuccessStatusCodes) == 0 {
+		return code >= 200 && code < 300
+	}
+
+	for _, c := range pa.SuccessStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (pa *ParticipantAction) requestActionHTTP(headers map[string]string, secret string) (*resty.Response, error) {
 	// build request
 	req := util.GetRequest().R()
 
+	if pa.TimeoutMs > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(pa.TimeoutMs)*time.Millisecond)
+		defer cancel()
+
+		req.SetContext(ctx)
+	}
+
+	if pa.Headers != nil {
+		req.SetHeaders(pa.Headers)
+	}
+
+	if pa.Name != "" {
+		req.SetHeader(ActionNameHeader, pa.Name)
+	}
+	req.SetHeader(AttemptHeader, strconv.Itoa(pa.InvokedCount+1))
+
+	// coordinator's headers can not be overridden by action's headers
+	if headers != nil {
+		req.SetHeaders(headers)
+	}
+
+	var body []byte
 	if pa.Data != nil {
-		req.SetBody(pa.Data)
+		var err error
+		if body, err = json.Marshal(pa.Data); err != nil {
+			return nil, err
+		}
+
+		req.SetHeader("Content-Type", "application/json").SetBody(body)
 	}
 
-	return req.Post(*pa.Uri)
+	signRequest(req, secret, req.Header.Get(InvocationIdHeader), body)
+
+	return req.Execute(pa.GetMethod(), *pa.Uri)
+}
+
+func validHeader(name string, value string) bool {
+	if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+		return false
+	}
+
+	return !strings.ContainsAny(value, "\r\n")
 }
 
 func (pa *ParticipantAction) ValidateAction() error {
@@ -93,11 +238,32 @@ func (pa *ParticipantAction) ValidateAction() error {
 		return ErrInvalidActionUri
 	}
 
+	if !actionMethods[pa.GetMethod()] {
+		return ErrInvalidActionMethod
+	}
+
+	for name, value := range pa.Headers {
+		if !validHeader(name, value) {
+			return fmt.Errorf("%w: %v", ErrInvalidActionHeader, name)
+		}
+	}
+
+	if pa.TimeoutMs < 0 {
+		return ErrInvalidActionTimeout
+	}
+
+	for _, code := range pa.SuccessStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("%w: %v", ErrInvalidActionStatusCode, code)
+		}
+	}
+
 	return nil
 }
 
-// invoke participant action and update it's result
-func (pa *ParticipantAction) InvokePartAction() error {
+// invoke participant action with extra headers and update it's result,
+// the request is signed by secret if it is not empty
+func (pa *ParticipantAction) InvokePartAction(headers map[string]string, secret string) error {
 	result := &PartActionResult{}
 
 	if pa.Status == PartActionCompleted {
@@ -108,7 +274,11 @@ func (pa *ParticipantAction) InvokePartAction() error {
 	err := pa.ValidateAction()
 
 	if err == nil {
-		err = result.ParseRestyResp(pa.requestActionHTTP())
+		err = result.ParseRestyResp(pa.requestActionHTTP(headers, secret))
+	}
+
+	if err == nil && !pa.IsSuccessStatusCode(result.StatusCode) {
+		err = fmt.Errorf("%w: unexpected status code %v", ErrActionRequestFailed, result.StatusCode)
 	}
 
 	if err != nil {
@@ -135,6 +305,11 @@ const (
 	ParticipantCompleting       ParticipantState = "Completing"
 	ParticipantCompleted        ParticipantState = "Completed"
 	ParticipantCompleteFailed   ParticipantState = "CompleteFailed"
+	// participant lease was expired before it committed, eg: the participant crashed
+	ParticipantFailed ParticipantState = "Failed"
+	// prepare action of two-phase commit was invoked
+	ParticipantPrepared      ParticipantState = "Prepared"
+	ParticipantPrepareFailed ParticipantState = "PrepareFailed"
 )
 
 type Participant struct {
@@ -146,8 +321,16 @@ type Participant struct {
 	State            ParticipantState   `json:"state" bson:"state"`
 	CompensateAction *ParticipantAction `json:"compensateAction,omitempty" bson:"compensateAction,omitempty"`
 	CompleteAction   *ParticipantAction `json:"completeAction,omitempty" bson:"completeAction,omitempty"`
-	UpdatedAt        *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
-	CreatedAt        *time.Time         `json:"createdAt" bson:"createdAt"`
+	// prepare action of two-phase commit session, success response is a yes vote
+	PrepareAction *ParticipantAction `json:"prepareAction,omitempty" bson:"prepareAction,omitempty"`
+	UpdatedAt     *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	CreatedAt     *time.Time         `json:"createdAt" bson:"createdAt"`
+	// lease of active participant, it is renewed by heartbeat. No lease if it is 0
+	LeaseTimeoutMs int        `json:"leaseTimeoutMs,omitempty" bson:"leaseTimeoutMs,omitempty"`
+	LeaseExpiredAt *time.Time `json:"leaseExpiredAt,omitempty" bson:"leaseExpiredAt,omitempty"`
+	// try-reservation of TCC session is held by the participant until this time,
+	// it must be confirmed or cancelled before
+	ReservationExpiredAt *time.Time `json:"reservationExpiredAt,omitempty" bson:"reservationExpiredAt,omitempty"`
 }
 
 func NewParticipant() *Participant {
@@ -169,9 +352,34 @@ func (p *Participant) GetAction(compensate bool) *ParticipantAction {
 	return p.CompleteAction
 }
 
+// HasLease returns true if the participant is active and holds a lease
+func (p *Participant) HasLease() bool {
+	return p.State == ParticipantActive && p.LeaseTimeoutMs > 0 && p.LeaseExpiredAt != nil
+}
+
+func (p *Participant) IsLeaseExpired() bool {
+	return p.HasLease() && p.LeaseExpiredAt.Before(time.Now())
+}
+
+func (p *Participant) RenewLease() {
+	if p.LeaseTimeoutMs <= 0 {
+		return
+	}
+
+	leaseExpiredAt := time.Now().Add(time.Duration(p.LeaseTimeoutMs) * time.Millisecond)
+	p.LeaseExpiredAt = &leaseExpiredAt
+}
+
+// HasReservation returns true if the participant holds a try-reservation which is not confirmed or cancelled yet
+func (p *Participant) HasReservation() bool {
+	return p.State == ParticipantCommitted && p.ReservationExpiredAt != nil
+}
+
 type ParticipantJoinBody struct {
 	ClientId  string `json:"clientId" validate:"required"`
 	RequestId string `json:"requestId"`
+	// participant must send heartbeat within this duration, otherwise it is failed
+	LeaseTimeoutMs int `json:"leaseTimeoutMs" validate:"min=0,max=3600000"`
 }
 
 func (p *ParticipantJoinBody) Validate() error {
@@ -184,11 +392,19 @@ type ParticipantUpdate struct {
 	State            *ParticipantState  `json:"state"`
 	CompensateAction *ParticipantAction `json:"compensateAction"`
 	CompleteAction   *ParticipantAction `json:"completeAction"`
+	PrepareAction    *ParticipantAction `json:"prepareAction"`
 	UpdatedAt        *time.Time         `json:"updatedAt"`
+	LeaseExpiredAt   *time.Time         `json:"leaseExpiredAt"`
+	// reservation is registered by partial-commit of TCC session
+	ReservationExpiredAt *time.Time `json:"reservationExpiredAt"`
 }
 
 type ParticipantCommit struct {
 	Id         *int64             `json:"participantId" validate:"required"`
 	Compensate *ParticipantAction `json:"compensate"`
 	Complete   *ParticipantAction `json:"complete"`
+	// required in two-phase commit session
+	Prepare *ParticipantAction `json:"prepare"`
+	// how long the try-reservation is held by the participant, required in TCC session
+	ReservationTimeoutMs int `json:"reservationTimeoutMs" validate:"min=0,max=86400000"`
 }
@@ -0,0 +1,197 @@
+package schema_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+func newAction(uri string) *schema.ParticipantAction {
+	return &schema.ParticipantAction{
+		Uri:    &uri,
+		Status: schema.PartActionCreated,
+	}
+}
+
+func TestActionIsFinished(t *testing.T) {
+	action := newAction("http://participant")
+	if action.IsFinished() {
+		t.Error("expected created action is not finished")
+	}
+
+	// failed action is retried beyond the default attempts of session retry policy
+	action.Status = schema.PartActionFailed
+	action.InvokedCount = schema.NewRetryPolicy().MaxAttempts
+	if action.IsFinished() {
+		t.Errorf("expected action invoked %v times is not finished", action.InvokedCount)
+	}
+
+	action.InvokedCount = schema.MAX_ACTION_INVOKED + 1
+	if !action.IsFinished() {
+		t.Errorf("expected action invoked %v times is finished", action.InvokedCount)
+	}
+
+	action.InvokedCount = 1
+	action.Status = schema.PartActionCompleted
+	if !action.IsFinished() {
+		t.Error("expected completed action is finished")
+	}
+}
+
+func TestInvokePartActionRequest(t *testing.T) {
+	var method, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	action := newAction(srv.URL)
+	action.Method = "put"
+	action.Headers = map[string]string{"Authorization": "Bearer token"}
+
+	if err := action.InvokePartAction(nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPut || auth != "Bearer token" {
+		t.Errorf("expected PUT with authorization header, got %v %q", method, auth)
+	}
+	// any 2xx is success by default
+	if action.Status != schema.PartActionCompleted || action.Results[0].StatusCode != http.StatusNoContent {
+		t.Errorf("expected completed action, got %+v", action)
+	}
+}
+
+func TestInvokePartActionStatusCodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	action := newAction(srv.URL)
+	if err := action.InvokePartAction(nil, ""); !errors.Is(err, schema.ErrActionRequestFailed) {
+		t.Errorf("expected %v, got %v", schema.ErrActionRequestFailed, err)
+	}
+	if action.Status != schema.PartActionFailed || action.InvokedCount != 1 {
+		t.Errorf("expected failed action, got %+v", action)
+	}
+
+	// eg: already deleted resource is a successful compensation
+	action.SuccessStatusCodes = []int{http.StatusOK, http.StatusNotFound}
+	if err := action.InvokePartAction(nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if action.Status != schema.PartActionCompleted || action.InvokedCount != 2 {
+		t.Errorf("expected completed action, got %+v", action)
+	}
+}
+
+func TestInvokePartActionTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	action := newAction(srv.URL)
+	action.TimeoutMs = 50
+
+	start := time.Now()
+	if err := action.InvokePartAction(nil, ""); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("action was not timed out, took %v", elapsed)
+	}
+	if action.Status != schema.PartActionFailed || action.Results[0].Error == "" {
+		t.Errorf("expected failed action with error, got %+v", action.Results[0])
+	}
+}
+
+func TestValidateAction(t *testing.T) {
+	cases := map[string]func(*schema.ParticipantAction){
+		"method":     func(a *schema.ParticipantAction) { a.Method = "CONNECT" },
+		"header":     func(a *schema.ParticipantAction) { a.Headers = map[string]string{"X-Bad": "a\r\nb"} },
+		"timeout":    func(a *schema.ParticipantAction) { a.TimeoutMs = -1 },
+		"statusCode": func(a *schema.ParticipantAction) { a.SuccessStatusCodes = []int{999} },
+	}
+
+	for name, invalidate := range cases {
+		action := newAction("http://localhost/action")
+		invalidate(action)
+
+		if err := action.ValidateAction(); !errors.Is(err, exception.ErrInvalidArgument) {
+			t.Errorf("%v: expected invalid argument, got %v", name, err)
+		}
+	}
+
+	if err := newAction("http://localhost/action").ValidateAction(); err != nil {
+		t.Errorf("expected valid action, got %v", err)
+	}
+}
+
+func TestInvokePartActionFencingToken(t *testing.T) {
+	var token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get(schema.FencingTokenHeader)
+	}))
+	defer srv.Close()
+
+	session := schema.NewSession(schema.NewSessionOption())
+	session.LockToken = 7
+
+	action := newAction(srv.URL)
+	// participant is not able to forge the token
+	action.Headers = map[string]string{schema.FencingTokenHeader: "1000"}
+
+	if err := action.InvokePartAction(session.GetActionHeaders(), ""); err != nil {
+		t.Fatal(err)
+	}
+	if token != "7" {
+		t.Errorf("expected fencing token 7, got %q", token)
+	}
+}
+
+func TestInvokePartActionSigned(t *testing.T) {
+	var header http.Header
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	session := schema.NewSession(schema.NewSessionOption())
+	part := &schema.Participant{Id: 3}
+	action := newAction(srv.URL)
+	action.Name = "charge"
+	action.Data = map[string]string{"orderId": "o-1"}
+
+	if err := action.InvokePartAction(session.GetPartActionHeaders(part, schema.ActionCompensate), "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	invocationId := schema.InvocationId(session.Id, part.Id, schema.ActionCompensate)
+	if header.Get(schema.InvocationIdHeader) != invocationId {
+		t.Errorf("expected invocation id %q, got %q", invocationId, header.Get(schema.InvocationIdHeader))
+	}
+	if header.Get(schema.ActionNameHeader) != "charge" || header.Get(schema.AttemptHeader) != "1" {
+		t.Errorf("unexpected action name %q, attempt %q", header.Get(schema.ActionNameHeader), header.Get(schema.AttemptHeader))
+	}
+
+	signature := schema.SignAction("secret", header.Get(schema.TimestampHeader), invocationId, body)
+	if header.Get(schema.SignatureHeader) != signature {
+		t.Errorf("expected signature %q, got %q", signature, header.Get(schema.SignatureHeader))
+	}
+}
@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultRetryInitialDelayMs = 1000 // 1 second
+	defaultRetryMultiplier     = 2.0
+	defaultRetryMaxDelayMs     = 60000 // 1 min
+	defaultRetryJitter         = 0.2
+	defaultRetryMaxAttempts    = 5
+)
+
+// RetryPolicy controls how failed participant actions of a session are retried.
+// The delay before n-th retry is InitialDelayMs * Multiplier^(n-1) capped at MaxDelayMs,
+// then randomized in range of +/- Jitter fraction of it.
+type RetryPolicy struct {
+	InitialDelayMs int     `json:"initialDelayMs" bson:"initialDelayMs" validate:"min=0"`
+	Multiplier     float64 `json:"multiplier" bson:"multiplier" validate:"min=1"`
+	MaxDelayMs     int     `json:"maxDelayMs" bson:"maxDelayMs" validate:"min=0"`
+	Jitter         float64 `json:"jitter" bson:"jitter" validate:"min=0,max=1"`
+	// maximum number of commit/abort/terminate attempts, including the first one
+	MaxAttempts int `json:"maxAttempts" bson:"maxAttempts" validate:"min=1"`
+}
+
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialDelayMs: defaultRetryInitialDelayMs,
+		Multiplier:     defaultRetryMultiplier,
+		MaxDelayMs:     defaultRetryMaxDelayMs,
+		Jitter:         defaultRetryJitter,
+		MaxAttempts:    defaultRetryMaxAttempts,
+	}
+}
+
+// NextDelay returns the delay before the given retry, retry starts from 1
+func (p *RetryPolicy) NextDelay(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+
+	delay := float64(p.InitialDelayMs) * math.Pow(p.Multiplier, float64(retry-1))
+	if delay > float64(p.MaxDelayMs) {
+		delay = float64(p.MaxDelayMs)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay) * time.Millisecond
+}
+
+func (p *RetryPolicy) IsExhausted(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
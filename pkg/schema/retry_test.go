@@ -0,0 +1,59 @@
+package schema_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+func TestRetryPolicyNextDelay(t *testing.T) {
+	policy := &schema.RetryPolicy{
+		InitialDelayMs: 100,
+		Multiplier:     2,
+		MaxDelayMs:     500,
+		MaxAttempts:    5,
+	}
+
+	expected := []time.Duration{100, 100, 200, 400, 500, 500}
+	for retry, delay := range expected {
+		if got := policy.NextDelay(retry); got != delay*time.Millisecond {
+			t.Errorf("retry %v: expected %v, got %v", retry, delay*time.Millisecond, got)
+		}
+	}
+}
+
+func TestRetryPolicyJitter(t *testing.T) {
+	policy := &schema.RetryPolicy{
+		InitialDelayMs: 1000,
+		Multiplier:     1,
+		MaxDelayMs:     1000,
+		Jitter:         0.5,
+		MaxAttempts:    5,
+	}
+
+	for i := 0; i < 100; i++ {
+		if got := policy.NextDelay(1); got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("expected delay in 500ms..1500ms, got %v", got)
+		}
+	}
+}
+
+func TestSessionRetryPolicy(t *testing.T) {
+	session := schema.NewSession(schema.NewSessionOption())
+	if session.RetryPolicy == nil {
+		t.Fatal("session must have default retry policy")
+	}
+
+	session.RetryPolicy = nil
+	session.Retries = schema.NewRetryPolicy().MaxAttempts
+	if !session.IsMaximumRetry() {
+		t.Errorf("session without retry policy must use default policy")
+	}
+
+	opts := schema.NewSessionOption()
+	opts.RetryPolicy.Multiplier = 0.5
+	if err := opts.Validate(); err == nil {
+		t.Errorf("multiplier less than 1 must be invalid")
+	}
+}
@@ -2,8 +2,10 @@ package schema
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
+	"github.com/barrydevp/transcoorditor/pkg/common"
 	"github.com/barrydevp/transcoorditor/pkg/util"
 	"github.com/google/uuid"
 )
@@ -23,17 +25,68 @@ const (
 	SessionTerminating     SessionState = "Terminating"
 	SessionTerminated      SessionState = "Terminated" // timeout session was auto terminated
 	SessionTerminateFailed SessionState = "TerminateFailed"
+	// prepare actions of two-phase commit session are being invoked
+	SessionPreparing SessionState = "Preparing"
+)
+
+type SessionMode string
+
+const (
+	// participants complete/compensate by callbacks after partial-commit
+	SessionSaga SessionMode = "saga"
+	// participants are asked to prepare and vote before the commit decision
+	SessionTwoPhaseCommit SessionMode = "2pc"
+	// partial-commit is a try-reservation with its own expiry, complete confirms and compensate cancels it
+	SessionTCC SessionMode = "tcc"
+)
+
+type SessionDecision string
 
-	SessionMaximumRetry = 5
+const (
+	DecisionCommit SessionDecision = "commit"
+	DecisionAbort  SessionDecision = "abort"
 )
 
 type SessionOptions struct {
 	Timeout int     `json:"timeout"`
 	LockKey *string `json:"lockKey"`
+	// keys are acquired all at once together with LockKey
+	LockKeys    []string     `json:"lockKeys" validate:"max=32,dive,required"`
+	RetryPolicy *RetryPolicy `json:"retryPolicy"`
+	// number of participant actions are invoked at the same time on commit/abort
+	Concurrency int `json:"concurrency" validate:"min=1,max=64"`
+	// invoke participant actions one by one in join order, concurrency is ignored
+	PreserveOrder bool `json:"preserveOrder"`
+	// wait at most this duration for the lock of LockKey when it is held, no wait if it is 0
+	LockWaitTimeoutMs int `json:"lockWaitTimeoutMs" validate:"min=0,max=300000"`
+	// lock mode of LockKey, sessions which only read the resource should request shared lock
+	LockMode LockMode `json:"lockMode" validate:"omitempty,oneof=exclusive shared"`
+	// order of compensate actions on abort/terminate
+	CompensationOrder CompensationOrder `json:"compensationOrder" validate:"omitempty,oneof=parallel forward reverse"`
+	// abort session as soon as a participant lease is expired, instead of waiting for session timeout
+	AbortOnParticipantFailure bool `json:"abortOnParticipantFailure"`
+	// transaction model of the session, default is saga
+	Mode SessionMode `json:"mode" validate:"omitempty,oneof=saga 2pc tcc"`
+	// urls which the final state of session is posted to, see SessionNotification
+	NotifyUrls []string `json:"notifyUrls" validate:"max=8,dive,url"`
+	// post intermediate states too, eg: Committing, CommitFailed
+	NotifyIntermediate bool `json:"notifyIntermediate"`
 }
 
+type CompensationOrder string
+
+const (
+	// compensate by the same way of complete, see Concurrency and PreserveOrder
+	CompensationParallel CompensationOrder = "parallel"
+	// compensate one by one in join order
+	CompensationForward CompensationOrder = "forward"
+	// compensate one by one in reverse join order (saga), stop at the first failed compensation
+	CompensationReverse CompensationOrder = "reverse"
+)
+
 const (
 	defaultSessionTimeout = 120 // 2 mins
+	defaultConcurrency    = 1
 )
 
 var (
@@ -41,7 +94,15 @@ var (
 )
 
 func NewSessionOption() *SessionOptions {
-	return &SessionOptions{Timeout: defaultSessionTimeout}
+	return &SessionOptions{
+		Timeout:     defaultSessionTimeout,
+		RetryPolicy: NewRetryPolicy(),
+		Concurrency: defaultConcurrency,
+	}
+}
+
+func (opts *SessionOptions) Validate() error {
+	return common.GetValidate().Struct(opts)
 }
 
 type Session struct {
@@ -58,7 +119,30 @@ type Session struct {
 	Retries         int          `json:"retries" bson:"retries"`
 	TerminateReason string       `json:"terminateReason,omitempty" bson:"terminateReason,omitempty"`
 
-	LockKey *string `json:"lockKey,omitempty" bson:"lockKey,omitempty"`
+	LockKey  *string  `json:"lockKey,omitempty" bson:"lockKey,omitempty"`
+	LockKeys []string `json:"lockKeys,omitempty" bson:"lockKeys,omitempty"`
+	// fencing token of the lock which was acquired when session started
+	LockToken uint64 `json:"lockToken,omitempty" bson:"lockToken,omitempty"`
+	// how long to wait for the lock when session starts
+	LockWaitTimeoutMs int      `json:"lockWaitTimeoutMs,omitempty" bson:"lockWaitTimeoutMs,omitempty"`
+	LockMode          LockMode `json:"lockMode,omitempty" bson:"lockMode,omitempty"`
+
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty" bson:"retryPolicy,omitempty"`
+	// when the failed session will be retried, it is only meaningful in failed states
+	NextRetryAt *time.Time `json:"nextRetryAt,omitempty" bson:"nextRetryAt,omitempty"`
+
+	Concurrency               int               `json:"concurrency,omitempty" bson:"concurrency,omitempty"`
+	PreserveOrder             bool              `json:"preserveOrder,omitempty" bson:"preserveOrder,omitempty"`
+	CompensationOrder         CompensationOrder `json:"compensationOrder,omitempty" bson:"compensationOrder,omitempty"`
+	AbortOnParticipantFailure bool              `json:"abortOnParticipantFailure,omitempty" bson:"abortOnParticipantFailure,omitempty"`
+
+	Mode SessionMode `json:"mode,omitempty" bson:"mode,omitempty"`
+	// outcome of two-phase commit votes, it is persisted before phase two
+	Decision SessionDecision `json:"decision,omitempty" bson:"decision,omitempty"`
+
+	// delivery of session state to notification urls
+	Notifications      []*SessionNotification `json:"notifications,omitempty" bson:"notifications,omitempty"`
+	NotifyIntermediate bool                   `json:"notifyIntermediate,omitempty" bson:"notifyIntermediate,omitempty"`
 
 	// for edges field (relations associate field)
 	Participants []*Participant `json:"participants,omitempty" bson:"-"`
@@ -73,18 +157,41 @@ type SessionUpdate struct {
 	StartedAt       *time.Time
 	Retries         *int
 	TerminateReason *string
+	NextRetryAt     *time.Time
+	Decision        *SessionDecision
+	Notifications   *[]*SessionNotification
+}
+
+type SessionKeepAliveBody struct {
+	// seconds from now until the session is timed out
+	Timeout int `json:"timeout" validate:"required,min=1"`
+}
+
+func (b *SessionKeepAliveBody) Validate() error {
+	return common.GetValidate().Struct(b)
 }
 
 func NewSession(opts *SessionOptions) *Session {
 	now := time.Now()
 
 	return &Session{
-		Id:           uuid.NewString(),
-		State:        SessionNew,
-		Timeout:      opts.Timeout,
-		CreatedAt:    &now,
-		Participants: nil,
-		LockKey:      opts.LockKey,
+		Id:                        uuid.NewString(),
+		State:                     SessionNew,
+		Timeout:                   opts.Timeout,
+		CreatedAt:                 &now,
+		Participants:              nil,
+		LockKey:                   opts.LockKey,
+		LockKeys:                  opts.LockKeys,
+		LockWaitTimeoutMs:         opts.LockWaitTimeoutMs,
+		LockMode:                  opts.LockMode,
+		RetryPolicy:               opts.RetryPolicy,
+		Concurrency:               opts.Concurrency,
+		PreserveOrder:             opts.PreserveOrder,
+		CompensationOrder:         opts.CompensationOrder,
+		AbortOnParticipantFailure: opts.AbortOnParticipantFailure,
+		Mode:                      opts.Mode,
+		Notifications:             newSessionNotifications(opts.NotifyUrls),
+		NotifyIntermediate:        opts.NotifyIntermediate,
 	}
 }
 
@@ -115,8 +222,77 @@ func (s *Session) IsFinished() bool {
 	// }
 }
 
+// GetRetryPolicy returns default policy for sessions which were started without one
+func (s *Session) GetRetryPolicy() *RetryPolicy {
+	if s.RetryPolicy == nil {
+		return NewRetryPolicy()
+	}
+
+	return s.RetryPolicy
+}
+
+// GetConcurrency returns number of participant actions can be invoked at the same time
+func (s *Session) GetConcurrency() int {
+	if s.PreserveOrder || s.Concurrency < 1 {
+		return 1
+	}
+
+	return s.Concurrency
+}
+
+func (s *Session) GetCompensationOrder() CompensationOrder {
+	if s.CompensationOrder == "" {
+		return CompensationParallel
+	}
+
+	return s.CompensationOrder
+}
+
+// GetLockKeys returns sorted keys of LockKey and LockKeys
+func (s *Session) GetLockKeys() []string {
+	keys := s.LockKeys
+	if s.LockKey != nil {
+		keys = append([]string{*s.LockKey}, keys...)
+	}
+
+	return SortLockKeys(keys)
+}
+
+func (s *Session) IsTwoPhaseCommit() bool {
+	return s.Mode == SessionTwoPhaseCommit
+}
+
+func (s *Session) IsTCC() bool {
+	return s.Mode == SessionTCC
+}
+
+// EarliestReservationExpiredAt returns the deadline of the first expiring reservation which is neither
+// confirmed nor cancelled, nil if there is none
+func (s *Session) EarliestReservationExpiredAt() *time.Time {
+	var earliest *time.Time
+	for _, part := range s.Participants {
+		if !part.HasReservation() {
+			continue
+		}
+
+		if earliest == nil || part.ReservationExpiredAt.Before(*earliest) {
+			earliest = part.ReservationExpiredAt
+		}
+	}
+
+	return earliest
+}
+
+func (s *Session) GetLockMode() LockMode {
+	if s.LockMode == "" {
+		return LockExclusive
+	}
+
+	return s.LockMode
+}
+
 func (s *Session) IsMaximumRetry() bool {
-	return s.Retries >= SessionMaximumRetry
+	return s.GetRetryPolicy().IsExhausted(s.Retries)
 }
 
 func UnfinishedSessionStates() []string {
@@ -132,6 +308,26 @@ func UnfinishedSessionStates() []string {
 	}
 }
 
+func (s *Session) IsUnfinished() bool {
+	for _, state := range UnfinishedSessionStates() {
+		if string(s.State) == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sessions in these states are in middle of ending, which is interrupted when leader was crashed
+func InProcessingSessionStates() []string {
+	return []string{
+		string(SessionPreparing),
+		string(SessionCommitting),
+		string(SessionAborting),
+		string(SessionTerminating),
+	}
+}
+
 func (s *Session) CheckSessionActive() error {
 	if s.State == SessionNew {
 		return util.Errorf("session was not started")
@@ -148,6 +344,17 @@ func (s *Session) CheckSessionActive() error {
 	return nil
 }
 
+// CheckNoPartFailed returns error if some participant lease was expired, the session is only able to abort
+func (s *Session) CheckNoPartFailed() error {
+	for _, part := range s.Participants {
+		if part.State == ParticipantFailed {
+			return ErrSessionHasFailedParticipant
+		}
+	}
+
+	return nil
+}
+
 func (s *Session) CheckAllPartAbleToEnd() error {
 	for _, part := range s.Participants {
 
@@ -165,19 +372,23 @@ func (s *Session) CheckAllPartAbleToEnd() error {
 }
 
 var (
-	ErrSessionIsCommitting       = errors.New("session is Committing")
-	ErrSessionWasCommitted       = errors.New("session was Committed")
-	ErrSessionWasCommitFailed    = errors.New("session was CommitFailed")
-	ErrSessionIsAborting         = errors.New("session is Aborting")
-	ErrSessionWasAborted         = errors.New("session was Aborted")
-	ErrSessionWasAbortFailed     = errors.New("session was AbortFailed")
-	ErrSessionIsTerminating      = errors.New("session is Terminating")
-	ErrSessionWasTerminated      = errors.New("session was Terminated")
-	ErrSessionWasTerminateFailed = errors.New("session was TerminateFailed")
+	ErrSessionIsPreparing          = errors.New("session is Preparing")
+	ErrSessionIsCommitting         = errors.New("session is Committing")
+	ErrSessionWasCommitted         = errors.New("session was Committed")
+	ErrSessionWasCommitFailed      = errors.New("session was CommitFailed")
+	ErrSessionIsAborting           = errors.New("session is Aborting")
+	ErrSessionWasAborted           = errors.New("session was Aborted")
+	ErrSessionWasAbortFailed       = errors.New("session was AbortFailed")
+	ErrSessionIsTerminating        = errors.New("session is Terminating")
+	ErrSessionWasTerminated        = errors.New("session was Terminated")
+	ErrSessionWasTerminateFailed   = errors.New("session was TerminateFailed")
+	ErrSessionHasFailedParticipant = errors.New("some participant was Failed")
 )
 
 func (s *Session) CheckInProcessing() error {
 	switch s.State {
+	case SessionPreparing:
+		return ErrSessionIsPreparing
 	case SessionCommitting:
 		return ErrSessionIsCommitting
 	case SessionAborting:
@@ -243,11 +454,44 @@ func (s *Session) AbleToCommitOrRollback(commit bool) error {
 		if err := s.CheckAllPartAbleToEnd(); err != nil {
 			return err
 		}
+
+		if commit {
+			if err := s.CheckNoPartFailed(); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// GetActionHeaders returns headers are sent with every participant action of the session
+func (s *Session) GetActionHeaders() map[string]string {
+	if s.LockToken == 0 {
+		return nil
+	}
+
+	return map[string]string{
+		FencingTokenHeader: strconv.FormatUint(s.LockToken, 10),
+	}
+}
+
+// GetPartActionHeaders returns headers of the given action of participant, including GetActionHeaders
+func (s *Session) GetPartActionHeaders(part *Participant, kind ActionKind) map[string]string {
+	headers := map[string]string{
+		SessionIdHeader:     s.Id,
+		ParticipantIdHeader: strconv.FormatInt(part.Id, 10),
+		ActionKindHeader:    string(kind),
+		InvocationIdHeader:  InvocationId(s.Id, part.Id, kind),
+	}
+
+	for name, value := range s.GetActionHeaders() {
+		headers[name] = value
+	}
+
+	return headers
+}
+
 func (s *Session) GetTerminateReason() string {
 	switch s.State {
 	case SessionCommitFailed:
@@ -262,11 +506,24 @@ func (s *Session) GetTerminateReason() string {
 }
 
 func (s *Session) GetParticipantAt(id int64) *Participant {
-	if len(s.Participants) < int(id) || s.Participants[id-1] == nil {
+	if id <= 0 {
 		return nil
 	}
 
-	return s.Participants[id-1]
+	// participant id is its position in session, look it up by id otherwise
+	if len(s.Participants) >= int(id) {
+		if part := s.Participants[id-1]; part != nil && part.Id == id {
+			return part
+		}
+	}
+
+	for _, part := range s.Participants {
+		if part != nil && part.Id == id {
+			return part
+		}
+	}
+
+	return nil
 }
 
 type SessionSearch struct {
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+// EventHandler is called synchronously by the emitter, it must not block
+type EventHandler func(event *schema.Event)
+
+type eventBus struct {
+	mutex    sync.RWMutex
+	nextId   int
+	handlers map[int]EventHandler
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		handlers: make(map[int]EventHandler),
+	}
+}
+
+// Subscribe registers handler for all events, the returned function unsubscribes it
+func (srv *Service) Subscribe(handler EventHandler) func() {
+	b := srv.events
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	id := b.nextId
+	b.nextId++
+	b.handlers[id] = handler
+
+	return func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		delete(b.handlers, id)
+	}
+}
+
+func (srv *Service) emit(event *schema.Event) {
+	b := srv.events
+
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+}
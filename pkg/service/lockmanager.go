@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,68 +13,271 @@ import (
 
 var globalLock = util.NewRWLockKey()
 
+var (
+	ErrLockWaitTimeout = fmt.Errorf("%w: wait timeout", store.ErrLockExists)
+)
+
+// SessionLockDuration is the duration of locks acquired by sessions
+const SessionLockDuration = 30 * time.Minute
+
 func (srv *Service) AcquireLock(key string, owner string, duration time.Duration) (*schema.LockEntry, error) {
-	globalLock.Lock(key)
-	defer globalLock.Unlock(key)
+	lockEnts, _, err := srv.acquireLocks([]string{key}, owner, schema.LockExclusive, duration, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockEnts[0], nil
+}
+
+// acquireLocks acquires all keys at once for owner, which is the waiter w or a non-waiting caller if w is nil.
+// keys must be sorted, the current lock entry is returned when a key is held in an incompatible mode
+func (srv *Service) acquireLocks(keys []string, owner string, mode schema.LockMode, duration time.Duration, w *lockWaiter) ([]*schema.LockEntry, *schema.LockEntry, error) {
+	for _, key := range keys {
+		globalLock.Lock(key)
+		defer globalLock.Unlock(key)
+	}
+
+	lockEnts := make([]*schema.LockEntry, 0, len(keys))
+	for _, key := range keys {
+		lockEnt, existLock, err := srv.prepareLock(key, owner, mode, duration, w)
+		if err != nil {
+			return nil, existLock, err
+		}
+		lockEnts = append(lockEnts, lockEnt)
+	}
 
+	// all-or-nothing, the entries are saved in a single replicated command
+	err := srv.s.LockTable().SaveMany(lockEnts)
+	if err != nil {
+		return nil, nil, exception.Errorf("failed to acquire lock: %w", err)
+	}
+
+	return lockEnts, nil, nil
+}
+
+// prepareLock returns the lock entry of key to be saved for owner,
+// the current lock entry is returned when the lock is held in an incompatible mode
+func (srv *Service) prepareLock(key string, owner string, mode schema.LockMode, duration time.Duration, w *lockWaiter) (*schema.LockEntry, *schema.LockEntry, error) {
 	existLock, err := srv.s.LockTable().Find(key)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if existLock != nil && existLock.IsShared() {
+		existLock.PruneHolders()
 	}
-	if existLock != nil && !existLock.IsExpired() {
-		return nil, fmt.Errorf("%w: owner(%v)", store.ErrLockExists, existLock.Owner)
+
+	if existLock == nil || existLock.IsExpired() {
+		// free lock is handed to waiters in FIFO order
+		if !srv.waiters.isHead(key, w) {
+			return nil, nil, exception.AppConflict(fmt.Errorf("%w: lock has waiters", store.ErrLockExists))
+		}
+
+		return schema.NewLockEntryWithMode(key, owner, mode, duration), nil, nil
 	}
 
-	lockEnt := schema.NewLockEntry(key, owner, duration)
-	err = srv.s.LockTable().Save(lockEnt)
+	if !existLock.IsShared() {
+		// the owner is able to re-acquire its lock, eg: retry of timed out request
+		if existLock.Owner != owner {
+			return nil, existLock, exception.AppConflict(fmt.Errorf("%w: owner(%v)", store.ErrLockExists, existLock.Owner))
+		}
+
+		return schema.NewLockEntryWithMode(key, owner, mode, duration), nil, nil
+	}
+
+	if mode == schema.LockShared {
+		// a new reader must not overtake waiters, eg: a waiting writer
+		if !existLock.HasOwner(owner) && !srv.waiters.isHead(key, w) {
+			return nil, existLock, exception.AppConflict(fmt.Errorf("%w: lock has waiters", store.ErrLockExists))
+		}
+
+		existLock.AddHolder(owner, duration)
+
+		return existLock, nil, nil
+	}
+
+	// the only holder of shared lock is able to upgrade it to exclusive lock
+	if len(existLock.Holders) != 1 || !existLock.HasOwner(owner) {
+		return nil, existLock, exception.AppConflict(fmt.Errorf("%w: shared by %v holder(s)", store.ErrLockExists, len(existLock.Holders)))
+	}
+
+	return schema.NewLockEntry(key, owner, duration), nil, nil
+}
+
+// AcquireLockWait acquires the lock in the given mode, if the lock is held in an incompatible mode
+// it waits in FIFO order at most maxWait until the lock is released or expired
+func (srv *Service) AcquireLockWait(key string, owner string, mode schema.LockMode, duration time.Duration, maxWait time.Duration) (*schema.LockEntry, error) {
+	lockEnts, err := srv.AcquireLocksWait([]string{key}, owner, mode, duration, maxWait)
 	if err != nil {
-		return nil, exception.Errorf("failed to acquire lock: %w", err)
+		return nil, err
 	}
 
-	return lockEnt, nil
+	return lockEnts[0], nil
+}
+
+// AcquireLocksWait acquires all keys at once or none of them, keys are sorted to avoid deadlock.
+// The same as AcquireLockWait, it waits at most maxWait until all keys are acquirable
+func (srv *Service) AcquireLocksWait(keys []string, owner string, mode schema.LockMode, duration time.Duration, maxWait time.Duration) ([]*schema.LockEntry, error) {
+	keys = schema.SortLockKeys(keys)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	lockEnts, _, err := srv.acquireLocks(keys, owner, mode, duration, nil)
+	if maxWait <= 0 || !errors.Is(err, store.ErrLockExists) {
+		return lockEnts, err
+	}
+
+	w := srv.waiters.enqueue(keys)
+	defer srv.waiters.remove(keys, w)
+
+	deadline := time.NewTimer(maxWait)
+	defer deadline.Stop()
+
+	// wake the head waiter up when the current lock is expired
+	var expiry *time.Timer
+	defer func() {
+		if expiry != nil {
+			expiry.Stop()
+		}
+	}()
+
+	for {
+		var expiryCh <-chan time.Time
+
+		if srv.waiters.isHeadAll(keys, w) {
+			lockEnts, existLock, err := srv.acquireLocks(keys, owner, mode, duration, w)
+			if !errors.Is(err, store.ErrLockExists) {
+				return lockEnts, err
+			}
+
+			if existLock != nil {
+				if expiry != nil {
+					expiry.Stop()
+				}
+				expiry = time.NewTimer(time.Until(*existLock.ExpiredAt))
+				expiryCh = expiry.C
+			}
+		}
+
+		select {
+		case <-w.wakeCh:
+		case <-expiryCh:
+		case <-deadline.C:
+			return nil, exception.AppConflict(ErrLockWaitTimeout)
+		}
+	}
 }
 
 func (srv *Service) ReleaseLock(key string, owner string) error {
-	lockEnt, err := srv.s.LockTable().FindWithOwner(key, owner)
+	globalLock.Lock(key)
+	defer globalLock.Unlock(key)
+
+	lockEnt, err := srv.s.LockTable().Find(key)
 	if err != nil {
 		return exception.Errorf("failed to get lock: %w", err)
 	}
 
-	if lockEnt == nil {
+	if lockEnt == nil || !lockEnt.HasOwner(owner) {
 		return nil
 	}
 
+	if lockEnt.IsShared() {
+		lockEnt.RemoveHolder(owner)
+		lockEnt.PruneHolders()
+
+		// other holders keep the lock
+		if len(lockEnt.Holders) > 0 {
+			if err := srv.s.LockTable().Update(lockEnt); err != nil {
+				return exception.Errorf("failed to release lock: %w", err)
+			}
+
+			srv.waiters.notify(lockEnt.Key)
+
+			return nil
+		}
+	}
+
 	return srv.ReleaseLock0(lockEnt)
 }
 
+// ReleaseLocks releases all keys held by owner, it continues on error and returns the first one
+func (srv *Service) ReleaseLocks(keys []string, owner string) error {
+	var firstErr error
+	for _, key := range keys {
+		if err := srv.ReleaseLock(key, owner); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
 func (srv *Service) ReleaseLock0(lockEnt *schema.LockEntry) error {
 	err := srv.s.LockTable().Delete(lockEnt)
 	if err != nil {
 		return exception.Errorf("failed to release lock: %w", err)
 	}
 
+	srv.waiters.notify(lockEnt.Key)
+
 	return nil
 }
 
 func (srv *Service) ExtendLock(key string, owner string, duration time.Duration) (*schema.LockEntry, error) {
+	return srv.extendLock(key, owner, func(time.Time) time.Duration {
+		return duration
+	})
+}
+
+// ExtendLockUntil extends the lock of owner so that it is alive at least until the given time
+func (srv *Service) ExtendLockUntil(key string, owner string, until time.Time) (*schema.LockEntry, error) {
+	return srv.extendLock(key, owner, func(expiredAt time.Time) time.Duration {
+		if until.After(expiredAt) {
+			return until.Sub(expiredAt)
+		}
+
+		return 0
+	})
+}
+
+// extendLock extends the lock of owner by the duration returned by extension from its current expiredAt
+func (srv *Service) extendLock(key string, owner string, extension func(expiredAt time.Time) time.Duration) (*schema.LockEntry, error) {
 	globalLock.Lock(key)
 	defer globalLock.Unlock(key)
 
-	lockEnt, err := srv.s.LockTable().FindWithOwner(key, owner)
+	lockEnt, err := srv.s.LockTable().Find(key)
 
 	if err != nil {
 		return nil, exception.Errorf("failed to get lock: %w", err)
 	}
 
-	if lockEnt.IsExpired() {
-		return nil, exception.Errorf("failed to extend lock: %w", store.ErrLockExpired)
+	if lockEnt == nil || !lockEnt.HasOwner(owner) {
+		return nil, exception.AppNotFound(store.ErrLockNotFound)
 	}
 
-	if lockEnt != nil {
-		return nil, exception.Errorf("failed to extend lock: %w", store.ErrLockNotFound)
+	var duration time.Duration
+	if lockEnt.IsShared() {
+		holder := lockEnt.GetHolder(owner)
+		if holder.IsExpired() {
+			return nil, exception.AppGone(store.ErrLockExpired)
+		}
+
+		duration = extension(*holder.ExpiredAt)
+		lockEnt.ExtendHolder(owner, duration)
+	} else {
+		if lockEnt.IsExpired() {
+			return nil, exception.AppGone(store.ErrLockExpired)
+		}
+
+		duration = extension(*lockEnt.ExpiredAt)
+		lockEnt.Extend(duration)
+	}
+
+	if duration <= 0 {
+		return lockEnt, nil
 	}
 
-	lockEnt.Extend(duration)
 	err = srv.s.LockTable().Update(lockEnt)
 	if err != nil {
 		return lockEnt, exception.Errorf("failed to extend lock: %w", err)
@@ -81,3 +285,73 @@ func (srv *Service) ExtendLock(key string, owner string, duration time.Duration)
 
 	return lockEnt, nil
 }
+
+func (srv *Service) GetLock(key string) (*schema.LockEntry, error) {
+	lockEnt, err := srv.s.LockTable().Find(key)
+	if err != nil {
+		return nil, exception.Errorf("failed to get lock: %w", err)
+	}
+
+	if lockEnt == nil {
+		return nil, exception.AppNotFound(store.ErrLockNotFound)
+	}
+
+	return lockEnt, nil
+}
+
+func (srv *Service) ListLock() ([]*schema.LockEntry, error) {
+	docs, err := srv.s.LockTable().FindAll()
+	if err != nil {
+		return nil, exception.Errorf("failed to list locks: %w", err)
+	}
+
+	return docs, nil
+}
+
+// ReapLock deletes the lock of key if it is expired and emits EventLockExpired for its unfinished sessions.
+// The current expiredAt is returned if the lock is still alive, eg: it was extended
+func (srv *Service) ReapLock(key string) (*time.Time, error) {
+	globalLock.Lock(key)
+	defer globalLock.Unlock(key)
+
+	lockEnt, err := srv.s.LockTable().Find(key)
+	if err != nil {
+		return nil, exception.Errorf("failed to get lock: %w", err)
+	}
+
+	if lockEnt == nil {
+		return nil, nil
+	}
+
+	if !lockEnt.IsExpired() {
+		return lockEnt.ExpiredAt, nil
+	}
+
+	if err := srv.ReleaseLock0(lockEnt); err != nil {
+		return nil, err
+	}
+
+	owners := []string{lockEnt.Owner}
+	if lockEnt.IsShared() {
+		owners = owners[:0]
+		for _, h := range lockEnt.Holders {
+			owners = append(owners, h.Owner)
+		}
+	}
+
+	for _, owner := range owners {
+		// owner of lock acquired by api is not always a session
+		session, err := srv.s.Session().FindById(owner)
+		if err != nil {
+			srv.l.Errorf("failed to get session of expired lock %v: %v", key, err)
+			continue
+		}
+
+		if session != nil && session.IsUnfinished() {
+			srv.l.Warnf("lock %v of unfinished session %v has expired", key, session.Id)
+			srv.emit(schema.NewEvent(schema.EventLockExpired, key, session.Id, lockEnt))
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,585 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/service"
+	"github.com/barrydevp/transcoorditor/pkg/store"
+)
+
+func assertAppError(t *testing.T, err error, target error, status int) {
+	t.Helper()
+
+	if !errors.Is(err, target) {
+		t.Errorf("expected %v, got %v", target, err)
+	}
+
+	var appErr *exception.AppError
+	if !errors.As(err, &appErr) || appErr.Status() != status {
+		t.Errorf("expected status %v, got %v", status, err)
+	}
+}
+
+func TestAcquireLock(t *testing.T) {
+	srv := newService(t)
+
+	lockEnt, err := srv.AcquireLock("key", "owner", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockEnt.Key != "key" || lockEnt.Owner != "owner" {
+		t.Errorf("unexpected lock %+v", lockEnt)
+	}
+
+	_, err = srv.AcquireLock("key", "another", time.Minute)
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+
+	// re-acquire by owner
+	if _, err := srv.AcquireLock("key", "owner", time.Minute); err != nil {
+		t.Errorf("owner must be able to re-acquire its lock, got %v", err)
+	}
+
+	if err := srv.ReleaseLock("key", "another"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.GetLock("key"); err != nil {
+		t.Errorf("lock must not be released by another, got %v", err)
+	}
+
+	if err := srv.ReleaseLock("key", "owner"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = srv.GetLock("key")
+	assertAppError(t, err, store.ErrLockNotFound, http.StatusNotFound)
+
+	if _, err := srv.AcquireLock("key", "another", time.Minute); err != nil {
+		t.Errorf("released lock must be acquirable, got %v", err)
+	}
+}
+
+func TestAcquireExpiredLock(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLock("key", "owner", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := srv.AcquireLock("key", "another", time.Minute); err != nil {
+		t.Errorf("expired lock must be acquirable, got %v", err)
+	}
+}
+
+func TestExtendLock(t *testing.T) {
+	srv := newService(t)
+
+	lockEnt, err := srv.AcquireLock("key", "owner", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredAt := *lockEnt.ExpiredAt
+
+	lockEnt, err = srv.ExtendLock("key", "owner", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lockEnt.ExpiredAt.Equal(expiredAt.Add(time.Minute)) {
+		t.Errorf("expected expiredAt %v, got %v", expiredAt.Add(time.Minute), lockEnt.ExpiredAt)
+	}
+
+	lockEnt, err = srv.GetLock("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lockEnt.ExpiredAt.After(expiredAt) {
+		t.Errorf("extended lock was not persisted, got %v", lockEnt.ExpiredAt)
+	}
+
+	_, err = srv.ExtendLock("key", "another", time.Minute)
+	assertAppError(t, err, store.ErrLockNotFound, http.StatusNotFound)
+
+	if _, err := srv.AcquireLock("expired", "owner", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	_, err = srv.ExtendLock("expired", "owner", time.Minute)
+	assertAppError(t, err, store.ErrLockExpired, http.StatusGone)
+}
+
+func TestListLock(t *testing.T) {
+	srv := newService(t)
+
+	for _, key := range []string{"a", "b"} {
+		if _, err := srv.AcquireLock(key, "owner", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	locks, err := srv.ListLock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 2 {
+		t.Errorf("expected 2 locks, got %v", len(locks))
+	}
+}
+
+func TestAcquireLockWaitRelease(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLock("key", "owner", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := srv.AcquireLockWait("key", "waiter", schema.LockExclusive, time.Minute, 5*time.Second)
+		errCh <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := srv.ReleaseLock("key", "owner"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken up on release")
+	}
+
+	lockEnt, err := srv.GetLock("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockEnt.Owner != "waiter" {
+		t.Errorf("expected lock owned by waiter, got %v", lockEnt.Owner)
+	}
+}
+
+func TestAcquireLockWaitExpired(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLock("key", "owner", 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	lockEnt, err := srv.AcquireLockWait("key", "waiter", schema.LockExclusive, time.Minute, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockEnt.Owner != "waiter" {
+		t.Errorf("expected lock owned by waiter, got %v", lockEnt.Owner)
+	}
+}
+
+func TestAcquireLockWaitTimeout(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLock("key", "owner", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := srv.AcquireLockWait("key", "waiter", schema.LockExclusive, time.Minute, 20*time.Millisecond)
+	assertAppError(t, err, service.ErrLockWaitTimeout, http.StatusConflict)
+
+	// the timed out waiter must leave the queue
+	if err := srv.ReleaseLock("key", "owner"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AcquireLock("key", "another", time.Minute); err != nil {
+		t.Errorf("released lock must be acquirable, got %v", err)
+	}
+}
+
+func TestAcquireLockWaitFIFO(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLock("key", "owner", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	n := 3
+	acquiredCh := make(chan string, n)
+	for i := 0; i < n; i++ {
+		owner := fmt.Sprintf("waiter-%v", i)
+		go func() {
+			if _, err := srv.AcquireLockWait("key", owner, schema.LockExclusive, time.Minute, 5*time.Second); err != nil {
+				t.Error(err)
+				return
+			}
+			acquiredCh <- owner
+		}()
+		// make sure waiters are queued in order
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// non-waiting acquire must not jump the queue
+	if err := srv.ReleaseLock("key", "owner"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case owner := <-acquiredCh:
+			if expected := fmt.Sprintf("waiter-%v", i); owner != expected {
+				t.Errorf("expected %v acquired the lock, got %v", expected, owner)
+			}
+			if _, err := srv.AcquireLock("key", "intruder", time.Minute); !errors.Is(err, store.ErrLockExists) {
+				t.Errorf("expected %v, got %v", store.ErrLockExists, err)
+			}
+			if err := srv.ReleaseLock("key", owner); err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("waiter-%v was not woken up", i)
+		}
+	}
+}
+
+func TestStartSessionLockWait(t *testing.T) {
+	srv := newService(t)
+
+	key := "key"
+	if _, err := srv.AcquireLock(key, "owner", 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{LockKey: &key}))
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{
+		LockKey:           &key,
+		LockWaitTimeoutMs: 5000,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lockEnt, err := srv.GetLock(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockEnt.Owner != session.Id {
+		t.Errorf("expected lock owned by session %v, got %v", session.Id, lockEnt.Owner)
+	}
+}
+
+func TestAcquireSharedLock(t *testing.T) {
+	srv := newService(t)
+
+	for _, owner := range []string{"reader-1", "reader-2"} {
+		if _, err := srv.AcquireLockWait("key", owner, schema.LockShared, time.Minute, 0); err != nil {
+			t.Fatalf("shared lock must be acquirable by %v, got %v", owner, err)
+		}
+	}
+
+	lockEnt, err := srv.GetLock("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lockEnt.IsShared() || len(lockEnt.Holders) != 2 {
+		t.Errorf("expected shared lock with 2 holders, got %+v", lockEnt)
+	}
+
+	_, err = srv.AcquireLock("key", "writer", time.Minute)
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+
+	// shared lock is not released until all holders release it
+	if err := srv.ReleaseLock("key", "reader-1"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = srv.AcquireLock("key", "writer", time.Minute)
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+
+	if err := srv.ReleaseLock("key", "reader-2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AcquireLock("key", "writer", time.Minute); err != nil {
+		t.Errorf("released shared lock must be acquirable, got %v", err)
+	}
+
+	_, err = srv.AcquireLockWait("key", "reader-1", schema.LockShared, time.Minute, 0)
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+}
+
+func TestSharedLockHolderExpired(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLockWait("key", "reader-1", schema.LockShared, time.Millisecond, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AcquireLockWait("key", "reader-2", schema.LockShared, time.Minute, 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	_, err := srv.ExtendLock("key", "reader-1", time.Minute)
+	assertAppError(t, err, store.ErrLockExpired, http.StatusGone)
+
+	if _, err := srv.ExtendLock("key", "reader-2", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// the only alive holder is able to upgrade to exclusive lock
+	lockEnt, err := srv.AcquireLock("key", "reader-2", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockEnt.IsShared() || lockEnt.Owner != "reader-2" {
+		t.Errorf("expected exclusive lock of reader-2, got %+v", lockEnt)
+	}
+}
+
+func TestSharedLockWaitWriter(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLockWait("key", "reader-1", schema.LockShared, time.Minute, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := srv.AcquireLockWait("key", "writer", schema.LockExclusive, time.Minute, 5*time.Second)
+		errCh <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// a new reader must not overtake the waiting writer
+	_, err := srv.AcquireLockWait("key", "reader-2", schema.LockShared, time.Minute, 0)
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+
+	if err := srv.ReleaseLock("key", "reader-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("writer was not woken up on release")
+	}
+}
+
+func TestStartSessionSharedLock(t *testing.T) {
+	srv := newService(t)
+
+	key := "key"
+	opts := &schema.SessionOptions{LockKey: &key, LockMode: schema.LockShared}
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		session, err := srv.StartSession(schema.NewSession(opts))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, session.Id)
+	}
+
+	lockEnt, err := srv.GetLock(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		if !lockEnt.HasOwner(id) {
+			t.Errorf("expected session %v holds the lock, got %+v", id, lockEnt.Holders)
+		}
+	}
+
+	_, err = srv.StartSession(schema.NewSession(&schema.SessionOptions{LockKey: &key}))
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+}
+
+func TestAcquireLocksAllOrNothing(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLock("b", "another", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := srv.AcquireLocksWait([]string{"c", "b", "a"}, "owner", schema.LockExclusive, time.Minute, 0)
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+
+	for _, key := range []string{"a", "c"} {
+		_, err := srv.GetLock(key)
+		assertAppError(t, err, store.ErrLockNotFound, http.StatusNotFound)
+	}
+
+	if err := srv.ReleaseLock("b", "another"); err != nil {
+		t.Fatal(err)
+	}
+
+	lockEnts, err := srv.AcquireLocksWait([]string{"c", "b", "a", "b"}, "owner", schema.LockExclusive, time.Minute, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lockEnts) != 3 {
+		t.Fatalf("expected 3 locks, got %v", len(lockEnts))
+	}
+	for i, key := range []string{"a", "b", "c"} {
+		if lockEnts[i].Key != key || lockEnts[i].Token != lockEnts[0].Token {
+			t.Errorf("expected lock %v with token %v, got %+v", key, lockEnts[0].Token, lockEnts[i])
+		}
+	}
+}
+
+func TestAcquireLocksWait(t *testing.T) {
+	srv := newService(t)
+
+	if _, err := srv.AcquireLock("b", "another", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := srv.AcquireLocksWait([]string{"a", "b"}, "owner", schema.LockExclusive, time.Minute, 5*time.Second)
+		errCh <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// nothing is held while waiting
+	_, err := srv.GetLock("a")
+	assertAppError(t, err, store.ErrLockNotFound, http.StatusNotFound)
+
+	if err := srv.ReleaseLocks([]string{"a", "b"}, "another"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken up on release")
+	}
+}
+
+func TestSessionLockKeys(t *testing.T) {
+	srv := newService(t)
+
+	key := "b"
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{
+		Timeout:  60,
+		LockKey:  &key,
+		LockKeys: []string{"c", "a"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		lockEnt, err := srv.GetLock(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lockEnt.Owner != session.Id || lockEnt.Token != session.LockToken {
+			t.Errorf("expected lock %v of session with token %v, got %+v", key, session.LockToken, lockEnt)
+		}
+	}
+
+	_, err = srv.StartSession(schema.NewSession(&schema.SessionOptions{LockKeys: []string{"d", "c"}}))
+	assertAppError(t, err, store.ErrLockExists, http.StatusConflict)
+	_, err = srv.GetLock("d")
+	assertAppError(t, err, store.ErrLockNotFound, http.StatusNotFound)
+
+	if _, err := srv.AbortSession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	locks, err := srv.ListLock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 0 {
+		t.Errorf("expected all locks were released, got %+v", locks)
+	}
+}
+
+func TestReapLock(t *testing.T) {
+	srv := newService(t)
+
+	var events []*schema.Event
+	unsubscribe := srv.Subscribe(func(event *schema.Event) {
+		events = append(events, event)
+	})
+	defer unsubscribe()
+
+	lockEnt, err := srv.AcquireLock("alive", "owner", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredAt, err := srv.ReapLock("alive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expiredAt == nil || !expiredAt.Equal(*lockEnt.ExpiredAt) {
+		t.Errorf("expected alive lock expiredAt %v, got %v", lockEnt.ExpiredAt, expiredAt)
+	}
+
+	if _, err := srv.AcquireLock("expired", "owner", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	expiredAt, err = srv.ReapLock("expired")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expiredAt != nil {
+		t.Errorf("expected expired lock was reaped, got %v", expiredAt)
+	}
+	_, err = srv.GetLock("expired")
+	assertAppError(t, err, store.ErrLockNotFound, http.StatusNotFound)
+
+	// owner is not a session
+	if len(events) != 0 {
+		t.Errorf("expected no event, got %+v", events)
+	}
+
+	if expiredAt, err := srv.ReapLock("missing"); err != nil || expiredAt != nil {
+		t.Errorf("expected missing lock is ignored, got %v %v", expiredAt, err)
+	}
+}
+
+func TestReapLockOfUnfinishedSession(t *testing.T) {
+	srv := newService(t)
+
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 60}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// subscribe after the session was started, its state change is not the concern
+	var events []*schema.Event
+	unsubscribe := srv.Subscribe(func(event *schema.Event) {
+		events = append(events, event)
+	})
+	defer unsubscribe()
+	if _, err := srv.AcquireLockWait("key", session.Id, schema.LockShared, time.Millisecond, 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := srv.ReapLock("key"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %+v", events)
+	}
+	if events[0].Type != schema.EventLockExpired || events[0].Key != "key" || events[0].SessionId != session.Id {
+		t.Errorf("unexpected event %+v", events[0])
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"sync"
+)
+
+type lockWaiter struct {
+	// signaled when the waiter should retry to acquire the lock
+	wakeCh chan struct{}
+}
+
+// lockWaitQueue holds waiters of each lock key in FIFO order,
+// only the head waiter of a key is allowed to acquire the lock
+type lockWaitQueue struct {
+	mutex  sync.Mutex
+	queues map[string][]*lockWaiter
+}
+
+func newLockWaitQueue() *lockWaitQueue {
+	return &lockWaitQueue{
+		queues: make(map[string][]*lockWaiter),
+	}
+}
+
+// enqueue adds a waiter to the queues of all keys at once, so waiters are in the same order on every key
+func (q *lockWaitQueue) enqueue(keys []string) *lockWaiter {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	w := &lockWaiter{
+		wakeCh: make(chan struct{}, 1),
+	}
+	for _, key := range keys {
+		q.queues[key] = append(q.queues[key], w)
+	}
+
+	return w
+}
+
+func (q *lockWaitQueue) remove(keys []string, w *lockWaiter) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, key := range keys {
+		queue := q.queues[key]
+		for i, waiter := range queue {
+			if waiter == w {
+				queue = append(queue[:i], queue[i+1:]...)
+				break
+			}
+		}
+
+		if len(queue) == 0 {
+			delete(q.queues, key)
+			continue
+		}
+		q.queues[key] = queue
+
+		// the next waiter becomes head
+		q.wakeHead(key)
+	}
+}
+
+// isHead returns true if w is the head waiter of key, or there is no waiter when w is nil
+func (q *lockWaitQueue) isHead(key string, w *lockWaiter) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.isHead0(key, w)
+}
+
+// isHeadAll returns true if w is the head waiter of all keys
+func (q *lockWaitQueue) isHeadAll(keys []string, w *lockWaiter) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, key := range keys {
+		if !q.isHead0(key, w) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (q *lockWaitQueue) isHead0(key string, w *lockWaiter) bool {
+	queue := q.queues[key]
+	if len(queue) == 0 {
+		return w == nil
+	}
+
+	return queue[0] == w
+}
+
+// notify wakes the head waiter of key up, eg: the lock was released
+func (q *lockWaitQueue) notify(key string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.wakeHead(key)
+}
+
+func (q *lockWaitQueue) wakeHead(key string) {
+	if queue := q.queues[key]; len(queue) > 0 {
+		select {
+		case queue[0].wakeCh <- struct{}{}:
+		default:
+		}
+	}
+}
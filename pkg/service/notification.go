@@ -0,0 +1,92 @@
+package service
+
+import (
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/spf13/viper"
+)
+
+const defaultNotifyMaxAttempts = 10
+
+func notifyTimeout() time.Duration {
+	return time.Duration(viper.GetInt("NOTIFY_TIMEOUT_MS")) * time.Millisecond
+}
+
+// actionSigningSecret returns the secret which participant actions and notifications are signed by, empty means unsigned
+func actionSigningSecret() string {
+	return viper.GetString("ACTION_SIGNING_SECRET")
+}
+
+func notifyMaxAttempts() int {
+	if maxAttempts := viper.GetInt("NOTIFY_MAX_ATTEMPTS"); maxAttempts > 0 {
+		return maxAttempts
+	}
+
+	return defaultNotifyMaxAttempts
+}
+
+// emitStateChanged notifies subscribers that session has moved to its current state,
+// data is a copy of the session since the session keeps changing after it
+func (srv *Service) emitStateChanged(session *schema.Session) {
+	clone := *session
+
+	srv.emit(schema.NewEvent(schema.EventSessionStateChanged, "", session.Id, &clone))
+}
+
+// NotifySession delivers the state of session to its notification urls which are due.
+// Failed deliveries are retried with session retry policy until NOTIFY_MAX_ATTEMPTS,
+// the next delivery time is returned if there are pending notifications
+func (srv *Service) NotifySession(id string) (*time.Time, error) {
+	session, err := srv.GetSessionById(id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	state := session.NotifyState()
+	pending := session.PendingNotifications()
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	event := schema.NewSessionStateEvent(session)
+	attempted := false
+
+	for _, n := range pending {
+		if n.DueAt(state, now).After(now) {
+			continue
+		}
+
+		attempted = true
+		n.Attempt(state)
+
+		if err := n.Notify(event, notifyTimeout(), actionSigningSecret()); err != nil {
+			srv.l.Debugf("notify session %v to %v failed: %v", session.Id, n.Url, err)
+
+			var nextAttemptAt *time.Time
+			if n.Attempts < notifyMaxAttempts() {
+				next := time.Now().Add(session.GetRetryPolicy().NextDelay(n.Attempts))
+				nextAttemptAt = &next
+			}
+			n.Failed(err, nextAttemptAt)
+		} else {
+			n.Delivered(state)
+		}
+	}
+
+	if attempted {
+		if _, err := srv.s.Session().UpdateById(session.Id, &schema.SessionUpdate{
+			Notifications: &session.Notifications,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return session.NextNotifyAt(), nil
+}
+
+func (srv *Service) GetAllNotifyingSession() ([]*schema.Session, error) {
+
+	return srv.s.Session().FindAllNotifying()
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/spf13/viper"
+)
+
+// notifyServer records the states of session notifications, it fails the first `fails` requests
+type notifyServer struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	fails    int
+	eventIds []string
+	states   []schema.SessionState
+}
+
+func newNotifyServer(t *testing.T, fails int) *notifyServer {
+	ns := &notifyServer{fails: fails}
+	ns.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ns.mutex.Lock()
+		defer ns.mutex.Unlock()
+
+		if ns.fails > 0 {
+			ns.fails--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var event struct {
+			Id   string         `json:"id"`
+			Data schema.Session `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ns.eventIds = append(ns.eventIds, r.Header.Get(schema.EventIdHeader))
+		ns.states = append(ns.states, event.Data.State)
+	}))
+	t.Cleanup(ns.Close)
+
+	return ns
+}
+
+func TestNotifySession(t *testing.T) {
+	srv := newService(t)
+	ns := newNotifyServer(t, 0)
+	as := newActionServer(t, 0)
+
+	var changed []schema.SessionState
+	unsubscribe := srv.Subscribe(func(event *schema.Event) {
+		if event.Type == schema.EventSessionStateChanged {
+			changed = append(changed, event.Data.(*schema.Session).State)
+		}
+	})
+	defer unsubscribe()
+
+	opts := schema.NewSessionOption()
+	opts.NotifyUrls = []string{ns.URL}
+	session := startSession(t, srv, opts, 1, as.commit)
+
+	// intermediate states are not notified by default
+	if next, err := srv.NotifySession(session.Id); err != nil || next != nil {
+		t.Fatalf("expected no pending notification, got %v %v", next, err)
+	}
+
+	if _, err := srv.CommitSession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []schema.SessionState{schema.SessionStarted, schema.SessionActive, schema.SessionCommitting, schema.SessionCommitted}
+	if len(changed) != len(expected) {
+		t.Fatalf("expected state changes %v, got %v", expected, changed)
+	}
+	for i := range expected {
+		if changed[i] != expected[i] {
+			t.Errorf("expected state changes %v, got %v", expected, changed)
+		}
+	}
+
+	notifying, err := srv.GetAllNotifyingSession()
+	if err != nil || len(notifying) != 1 {
+		t.Fatalf("expected 1 notifying session, got %v %v", len(notifying), err)
+	}
+
+	if next, err := srv.NotifySession(session.Id); err != nil || next != nil {
+		t.Fatalf("expected notification was delivered, got %v %v", next, err)
+	}
+	// delivered state is not notified again
+	if _, err := srv.NotifySession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ns.states) != 1 || ns.states[0] != schema.SessionCommitted {
+		t.Errorf("expected Commited state was notified once, got %v", ns.states)
+	}
+	if ns.eventIds[0] != session.Id+"/"+string(schema.SessionCommitted) {
+		t.Errorf("unexpected event id %v", ns.eventIds[0])
+	}
+
+	session, err = srv.GetSessionById(session.Id, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := session.Notifications[0]; n.DeliveredState != schema.SessionCommitted || n.DeliveredAt == nil {
+		t.Errorf("expected delivery was persisted, got %+v", n)
+	}
+}
+
+func TestNotifySessionIntermediate(t *testing.T) {
+	srv := newService(t)
+	ns := newNotifyServer(t, 0)
+
+	opts := schema.NewSessionOption()
+	opts.NotifyUrls = []string{ns.URL}
+	opts.NotifyIntermediate = true
+	session, err := srv.StartSession(schema.NewSession(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := srv.NotifySession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AbortSession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.NotifySession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	// Aborting was passed before it was delivered
+	if len(ns.states) != 2 || ns.states[0] != schema.SessionStarted || ns.states[1] != schema.SessionAborted {
+		t.Errorf("expected Started and Aborted states were notified, got %v", ns.states)
+	}
+}
+
+func TestNotifySessionRetry(t *testing.T) {
+	srv := newService(t)
+	ns := newNotifyServer(t, 3)
+
+	viper.Set("NOTIFY_MAX_ATTEMPTS", 2)
+	defer viper.Set("NOTIFY_MAX_ATTEMPTS", nil)
+
+	opts := schema.NewSessionOption()
+	opts.NotifyUrls = []string{ns.URL}
+	// retry immediately
+	opts.RetryPolicy.InitialDelayMs = 0
+	session, err := srv.StartSession(schema.NewSession(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AbortSession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := srv.NotifySession(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == nil {
+		t.Fatal("expected failed notification was scheduled to retry")
+	}
+
+	// maximum attempts, given up
+	if next, err = srv.NotifySession(session.Id); err != nil || next != nil {
+		t.Fatalf("expected notification was given up, got %v %v", next, err)
+	}
+
+	session, err = srv.GetSessionById(session.Id, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := session.Notifications[0]; !n.GaveUp || n.Attempts != 2 || n.LastError == "" || n.DeliveredState != "" {
+		t.Errorf("expected notification was given up, got %+v", n)
+	}
+
+	notifying, err := srv.GetAllNotifyingSession()
+	if err != nil || len(notifying) != 0 {
+		t.Errorf("expected no notifying session, got %v %v", len(notifying), err)
+	}
+}
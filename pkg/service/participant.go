@@ -1,12 +1,20 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+
 	"github.com/barrydevp/transcoorditor/pkg/exception"
 	"github.com/barrydevp/transcoorditor/pkg/schema"
 )
 
 var (
-	ErrParticipantNotFound = exception.AppNotFoundf("participant not found")
+	ErrParticipantNotFound     = exception.AppNotFoundf("participant not found")
+	ErrParticipantLeaseExpired = exception.AppGonef("participant lease has been expired")
+
+	ErrParticipantNoPrepareAction = errors.New("participant has no prepare action")
+	ErrParticipantActionExhausted = errors.New("participant action has exhausted its attempts")
 )
 
 func (srv *Service) findParticipantById(sessionId string, id int64) (*schema.Participant, error) {
@@ -24,32 +32,127 @@ func (srv *Service) findParticipantById(sessionId string, id int64) (*schema.Par
 
 type PartActionHandler func(*schema.Participant) (*schema.ParticipantUpdate, error)
 
-func (srv *Service) handlePartAction(session *schema.Session, handler PartActionHandler) []string {
+// partActionOrder is the order which participant actions are invoked in
+type partActionOrder int
+
+const (
+	// bounded by the concurrency of session
+	partActionParallel partActionOrder = iota
+	// one by one in join order
+	partActionForward
+	// one by one in reverse join order, stops at the first failure
+	partActionReverse
+)
+
+func compensationActionOrder(order schema.CompensationOrder) partActionOrder {
+	switch order {
+	case schema.CompensationForward:
+		return partActionForward
+	case schema.CompensationReverse:
+		return partActionReverse
+	default:
+		return partActionParallel
+	}
+}
+
+// handlePartAction invokes handler on participants of session in the given order
+func (srv *Service) handlePartAction(session *schema.Session, order partActionOrder, handler PartActionHandler) []string {
 	if len(session.Participants) == 0 {
 		return nil
 	}
 
-	var errs []string
+	// errors of each participant, they are gathered in join order regardless of the execution order
+	partErrs := make([][]string, len(session.Participants))
 
-	for _, part := range session.Participants {
+	// returns true if the participant was failed
+	handle := func(i int) bool {
+		part := session.Participants[i]
 		partUpdate, err := handler(part)
 
 		if err != nil {
-			errs = append(errs, err.Error())
+			partErrs[i] = append(partErrs[i], fmt.Sprintf("participant %v: %v", part.Id, err))
 		}
 
 		// srv.l.Info(partUpdate)
 
 		if partUpdate != nil {
 			if _, err = srv.s.Participant().UpdateBySessionAndId(session.Id, part.Id, partUpdate); err != nil {
-				errs = append(errs, err.Error())
+				partErrs[i] = append(partErrs[i], fmt.Sprintf("participant %v: %v", part.Id, err))
 			}
 		}
+
+		return len(partErrs[i]) > 0
+	}
+
+	concurrency := session.GetConcurrency()
+	if concurrency > len(session.Participants) {
+		concurrency = len(session.Participants)
+	}
+
+	switch {
+	case order == partActionReverse:
+		// earlier participants are left untouched until the next retry
+		for i := len(session.Participants) - 1; i >= 0; i-- {
+			if handle(i) {
+				break
+			}
+		}
+	case order == partActionForward || concurrency <= 1:
+		for i := range session.Participants {
+			handle(i)
+		}
+	default:
+		// bounded worker pool
+		idxCh := make(chan int)
+		var wg sync.WaitGroup
+
+		for w := 0; w < concurrency; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for i := range idxCh {
+					handle(i)
+				}
+			}()
+		}
+
+		for i := range session.Participants {
+			idxCh <- i
+		}
+		close(idxCh)
+		wg.Wait()
+	}
+
+	var errs []string
+	for _, e := range partErrs {
+		errs = append(errs, e...)
 	}
 
 	return errs
 }
 
+// handlePrepareActions invokes prepare actions of two-phase commit session, each error is a no vote
+func (srv *Service) handlePrepareActions(session *schema.Session) []string {
+	return srv.handlePartAction(session, partActionParallel, func(part *schema.Participant) (*schema.ParticipantUpdate, error) {
+		action := part.PrepareAction
+		if action == nil {
+			return nil, ErrParticipantNoPrepareAction
+		}
+
+		partState := schema.ParticipantPrepared
+		err := action.InvokePartAction(session.GetPartActionHeaders(part, schema.ActionPrepare), actionSigningSecret())
+		if err != nil {
+			partState = schema.ParticipantPrepareFailed
+		}
+
+		return &schema.ParticipantUpdate{
+			State:         &partState,
+			PrepareAction: action,
+		}, err
+	})
+}
+
 func (srv *Service) handleParticipantActions(session *schema.Session, compensate bool) []string {
 	partOKState := schema.ParticipantCompleted
 	partERRState := schema.ParticipantCompleteFailed
@@ -59,18 +162,37 @@ func (srv *Service) handleParticipantActions(session *schema.Session, compensate
 		partERRState = schema.ParticipantCompensateFailed
 	}
 
-	return srv.handlePartAction(session, func(part *schema.Participant) (*schema.ParticipantUpdate, error) {
+	order := partActionParallel
+	if compensate {
+		order = compensationActionOrder(session.GetCompensationOrder())
+	}
+
+	return srv.handlePartAction(session, order, func(part *schema.Participant) (*schema.ParticipantUpdate, error) {
 		var err error
 
 		action := part.GetAction(compensate)
 		partState := partOKState
 
+		// participant lease was expired, it has nothing to complete or compensate
+		if part.State == schema.ParticipantFailed {
+			return nil, nil
+		}
+
 		if action != nil {
 			if action.IsFinished() {
+				// exhausted action is still a failure, otherwise reverse order walks past it
+				if action.Status != schema.PartActionCompleted {
+					return nil, ErrParticipantActionExhausted
+				}
 				return nil, nil
 			}
 
-			err = action.InvokePartAction()
+			kind := schema.ActionComplete
+			if compensate {
+				kind = schema.ActionCompensate
+			}
+
+			err = action.InvokePartAction(session.GetPartActionHeaders(part, kind), actionSigningSecret())
 			if err != nil {
 				partState = partERRState
 			}
@@ -89,3 +211,110 @@ func (srv *Service) handleParticipantActions(session *schema.Session, compensate
 		return update, err
 	})
 }
+
+// HeartbeatParticipant renews the lease of active participant
+func (srv *Service) HeartbeatParticipant(sessionId string, id int64) (*schema.Participant, error) {
+	session, err := srv.findSessionById(sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.CheckSessionActive(); err != nil {
+		return nil, exception.AppPreconditionFailed(err)
+	}
+
+	part, err := srv.findParticipantById(sessionId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if part.State == schema.ParticipantFailed || part.IsLeaseExpired() {
+		return nil, ErrParticipantLeaseExpired
+	}
+
+	// committed participant or participant without lease has nothing to renew
+	if !part.HasLease() {
+		return part, nil
+	}
+
+	part.RenewLease()
+	part, err = srv.s.Participant().UpdateBySessionAndId(sessionId, id, &schema.ParticipantUpdate{
+		LeaseExpiredAt: part.LeaseExpiredAt,
+	})
+	if err != nil {
+		return nil, exception.Errorf("failed to renew participant lease: %w", err)
+	}
+
+	return part, nil
+}
+
+// ExpireParticipantLease marks the participant Failed if its lease was expired, then aborts the session
+// if AbortOnParticipantFailure is set. The participant is returned with its current lease if it is still alive,
+// the session is returned if it was aborted
+func (srv *Service) ExpireParticipantLease(sessionId string, id int64) (*schema.Participant, *schema.Session, error) {
+	session, err := srv.findSessionById(sessionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// session has been ended by itself
+	if session.CheckSessionActive() != nil {
+		return nil, nil, nil
+	}
+
+	part, err := srv.findParticipantById(sessionId, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !part.HasLease() || !part.IsLeaseExpired() {
+		return part, nil, nil
+	}
+
+	srv.l.Warnf("lease of participant %v in session %v has expired", id, sessionId)
+
+	part.State = schema.ParticipantFailed
+	if _, err := srv.s.Participant().UpdateBySessionAndId(sessionId, id, &schema.ParticipantUpdate{
+		State: &part.State,
+	}); err != nil {
+		return nil, nil, exception.Errorf("failed to update participant: %w", err)
+	}
+
+	if !session.AbortOnParticipantFailure {
+		return part, nil, nil
+	}
+
+	session, err = srv.GetSessionById(sessionId, true)
+	if err != nil {
+		return part, nil, err
+	}
+
+	// the same as terminate, other participants are not waited for, they are unable to commit on aborted session
+	session, err = srv.endSession(session, Abort)
+
+	return part, session, err
+}
+
+// GetAllLeasedParticipant returns active participants holding a lease of unfinished sessions
+func (srv *Service) GetAllLeasedParticipant() ([]*schema.Participant, error) {
+	sessions, err := srv.GetAllUnFinishedSession()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*schema.Participant
+	for _, session := range sessions {
+		parts, err := srv.s.Participant().FindBySessionId(session.Id)
+		if err != nil {
+			return nil, exception.Errorf("failed to get participants: %w", err)
+		}
+
+		for _, part := range parts {
+			if part.HasLease() {
+				results = append(results, part)
+			}
+		}
+	}
+
+	return results, nil
+}
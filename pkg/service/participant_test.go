@@ -0,0 +1,611 @@
+package service_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/service"
+	"github.com/barrydevp/transcoorditor/pkg/store/memory"
+)
+
+// actionServer records invocations of participant actions, the path is /{participantId}
+type actionServer struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	inflight int
+	maxIn    int
+	order    []string
+	failed   map[string]bool
+	delay    time.Duration
+}
+
+func newActionServer(t *testing.T, delay time.Duration) *actionServer {
+	as := &actionServer{
+		failed: map[string]bool{},
+		delay:  delay,
+	}
+	as.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/")
+
+		as.mutex.Lock()
+		as.inflight++
+		if as.inflight > as.maxIn {
+			as.maxIn = as.inflight
+		}
+		as.order = append(as.order, id)
+		failed := as.failed[id]
+		as.mutex.Unlock()
+
+		time.Sleep(as.delay)
+
+		as.mutex.Lock()
+		as.inflight--
+		as.mutex.Unlock()
+
+		if failed {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(as.Close)
+
+	return as
+}
+
+// commitBuilder returns the partial commit of the i-th participant of session
+type commitBuilder func(i int, part *schema.Participant) *schema.ParticipantCommit
+
+// startSession starts a session with n participants, each of them is partial committed by commit
+func startSession(t *testing.T, srv *service.Service, opts *schema.SessionOptions, n int, commit commitBuilder) *schema.Session {
+	t.Helper()
+
+	session, err := srv.StartSession(schema.NewSession(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < n; i++ {
+		part := schema.NewParticipant()
+		part.SessionId = session.Id
+		part.ClientId = fmt.Sprintf("client-%v", i)
+		if part, err = srv.JoinSession(session.Id, part); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := srv.PartialCommitSession(session.Id, commit(i, part)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return session
+}
+
+// commit builds complete and compensate actions of participant on the action server
+func (as *actionServer) commit(i int, part *schema.Participant) *schema.ParticipantCommit {
+	uri := fmt.Sprintf("%v/%v", as.URL, part.Id)
+
+	return &schema.ParticipantCommit{
+		Id:         &part.Id,
+		Complete:   &schema.ParticipantAction{Uri: &uri},
+		Compensate: &schema.ParticipantAction{Uri: &uri},
+	}
+}
+
+func newService(t *testing.T) *service.Service {
+	s, err := memory.NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	return service.NewService(s)
+}
+
+func TestCommitConcurrency(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 50*time.Millisecond)
+
+	opts := schema.NewSessionOption()
+	opts.Concurrency = 4
+	session := startSession(t, srv, opts, 12, as.commit)
+
+	session, err := srv.CommitSession(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.State != schema.SessionCommitted {
+		t.Errorf("expected committed session, got %v", session.State)
+	}
+	if len(as.order) != 12 {
+		t.Errorf("expected 12 invocations, got %v", len(as.order))
+	}
+	if as.maxIn > 4 || as.maxIn < 2 {
+		t.Errorf("expected at most 4 concurrent invocations, got %v", as.maxIn)
+	}
+
+	session, err = srv.GetSessionById(session.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range session.Participants {
+		if part.State != schema.ParticipantCompleted {
+			t.Errorf("participant %v was not completed, got %v", part.Id, part.State)
+		}
+	}
+}
+
+func TestCommitPreserveOrder(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, time.Millisecond)
+
+	opts := schema.NewSessionOption()
+	opts.Concurrency = 4
+	opts.PreserveOrder = true
+	session := startSession(t, srv, opts, 5, as.commit)
+
+	if _, err := srv.CommitSession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+	if as.maxIn != 1 {
+		t.Errorf("expected sequential invocations, got %v concurrent", as.maxIn)
+	}
+	if got := strings.Join(as.order, ","); got != "1,2,3,4,5" {
+		t.Errorf("expected join order, got %v", got)
+	}
+}
+
+func TestCommitErrorsOrder(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, time.Millisecond)
+	as.failed["5"] = true
+	as.failed["2"] = true
+
+	opts := schema.NewSessionOption()
+	opts.Concurrency = 3
+	session := startSession(t, srv, opts, 6, as.commit)
+
+	session, err := srv.CommitSession(session.Id)
+	if err == nil {
+		t.Fatal("expected commit failed")
+	}
+	if session.State != schema.SessionCommitFailed {
+		t.Errorf("expected commit failed session, got %v", session.State)
+	}
+	if len(session.Errors) != 2 || !strings.HasPrefix(session.Errors[0], "participant 2:") ||
+		!strings.HasPrefix(session.Errors[1], "participant 5:") {
+		t.Errorf("expected errors of participant 2 and 5 in order, got %v", session.Errors)
+	}
+}
+
+func TestAbortReverseCompensation(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, time.Millisecond)
+	as.failed["3"] = true
+
+	opts := schema.NewSessionOption()
+	opts.Concurrency = 4
+	opts.CompensationOrder = schema.CompensationReverse
+	session := startSession(t, srv, opts, 5, as.commit)
+
+	session, err := srv.AbortSession(session.Id)
+	if err == nil {
+		t.Fatal("expected abort failed")
+	}
+	if got := strings.Join(as.order, ","); got != "5,4,3" {
+		t.Errorf("expected compensation stopped at participant 3, got %v", got)
+	}
+	if len(session.Errors) != 1 || !strings.HasPrefix(session.Errors[0], "participant 3:") {
+		t.Errorf("expected error of participant 3, got %v", session.Errors)
+	}
+
+	session, err = srv.GetSessionById(session.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []schema.ParticipantState{
+		schema.ParticipantCommitted,
+		schema.ParticipantCommitted,
+		schema.ParticipantCompensateFailed,
+		schema.ParticipantCompensated,
+		schema.ParticipantCompensated,
+	}
+	for i, part := range session.Participants {
+		if part.State != expected[i] {
+			t.Errorf("participant %v: expected %v, got %v", part.Id, expected[i], part.State)
+		}
+	}
+
+	// retry continues from the failed compensation
+	as.failed["3"] = false
+	as.order = nil
+	session, err = srv.AbortSession(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.State != schema.SessionAborted {
+		t.Errorf("expected aborted session, got %v", session.State)
+	}
+	if got := strings.Join(as.order, ","); got != "3,2,1" {
+		t.Errorf("expected remaining compensations in reverse order, got %v", got)
+	}
+}
+
+func TestAbortReverseCompensationExhausted(t *testing.T) {
+	s, err := memory.NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	srv := service.NewService(s)
+	as := newActionServer(t, time.Millisecond)
+
+	opts := schema.NewSessionOption()
+	opts.CompensationOrder = schema.CompensationReverse
+	session := startSession(t, srv, opts, 3, as.commit)
+
+	// compensation of participant 2 has failed on all of its attempts
+	uri := fmt.Sprintf("%v/%v", as.URL, 2)
+	if _, err := s.Participant().UpdateBySessionAndId(session.Id, 2, &schema.ParticipantUpdate{
+		CompensateAction: &schema.ParticipantAction{
+			Uri:          &uri,
+			Status:       schema.PartActionFailed,
+			InvokedCount: schema.MAX_ACTION_INVOKED + 1,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err = srv.AbortSession(session.Id)
+	if err == nil {
+		t.Fatal("expected abort failed")
+	}
+	if got := strings.Join(as.order, ","); got != "3" {
+		t.Errorf("expected compensation stopped at participant 2, got %v", got)
+	}
+	if len(session.Errors) != 1 || !strings.HasPrefix(session.Errors[0], "participant 2:") {
+		t.Errorf("expected error of participant 2, got %v", session.Errors)
+	}
+}
+
+func TestAbortForwardCompensation(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, time.Millisecond)
+	as.failed["2"] = true
+
+	opts := schema.NewSessionOption()
+	opts.Concurrency = 4
+	opts.CompensationOrder = schema.CompensationForward
+	session := startSession(t, srv, opts, 4, as.commit)
+
+	if _, err := srv.AbortSession(session.Id); err == nil {
+		t.Fatal("expected abort failed")
+	}
+	// forward order does not stop at the failed compensation
+	if got := strings.Join(as.order, ","); got != "1,2,3,4" {
+		t.Errorf("expected compensations in join order, got %v", got)
+	}
+}
+
+func joinWithLease(t *testing.T, srv *service.Service, sessionId string, leaseTimeoutMs int) *schema.Participant {
+	t.Helper()
+
+	part := schema.NewParticipant()
+	part.SessionId = sessionId
+	part.ClientId = "client"
+	part.LeaseTimeoutMs = leaseTimeoutMs
+
+	part, err := srv.JoinSession(sessionId, part)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return part
+}
+
+func TestHeartbeatParticipant(t *testing.T) {
+	srv := newService(t)
+
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 60}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part := joinWithLease(t, srv, session.Id, 50)
+	if !part.HasLease() {
+		t.Fatalf("expected participant holds a lease, got %+v", part)
+	}
+	leaseExpiredAt := *part.LeaseExpiredAt
+
+	time.Sleep(10 * time.Millisecond)
+	part, err = srv.HeartbeatParticipant(session.Id, part.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !part.LeaseExpiredAt.After(leaseExpiredAt) {
+		t.Errorf("expected lease was renewed after %v, got %v", leaseExpiredAt, part.LeaseExpiredAt)
+	}
+
+	// lease is still alive
+	if _, aborted, err := srv.ExpireParticipantLease(session.Id, part.Id); err != nil || aborted != nil {
+		t.Errorf("expected alive lease is not expired, got %v %v", aborted, err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = srv.HeartbeatParticipant(session.Id, part.Id)
+	assertAppError(t, err, service.ErrParticipantLeaseExpired, http.StatusGone)
+}
+
+func TestExpireParticipantLease(t *testing.T) {
+	srv := newService(t)
+
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 60}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part := joinWithLease(t, srv, session.Id, 1)
+	time.Sleep(5 * time.Millisecond)
+
+	part, aborted, err := srv.ExpireParticipantLease(session.Id, part.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.State != schema.ParticipantFailed || aborted != nil {
+		t.Errorf("expected failed participant without abort, got %+v %+v", part, aborted)
+	}
+
+	// session with failed participant is unable to commit, but able to abort
+	_, err = srv.CommitSession(session.Id)
+	assertAppError(t, err, schema.ErrSessionHasFailedParticipant, http.StatusPreconditionFailed)
+
+	session, err = srv.AbortSession(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.State != schema.SessionAborted {
+		t.Errorf("expected aborted session, got %v", session.State)
+	}
+
+	part, err = srv.HeartbeatParticipant(session.Id, part.Id)
+	if err == nil {
+		t.Errorf("expected heartbeat on aborted session failed, got %+v", part)
+	}
+}
+
+func TestExpireParticipantLeaseAutoAbort(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 0)
+
+	session := startSession(t, srv, &schema.SessionOptions{Timeout: 60, AbortOnParticipantFailure: true}, 1, as.commit)
+	// another participant is still working
+	joinWithLease(t, srv, session.Id, 60000)
+	part := joinWithLease(t, srv, session.Id, 1)
+	time.Sleep(5 * time.Millisecond)
+
+	_, aborted, err := srv.ExpireParticipantLease(session.Id, part.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aborted == nil || aborted.State != schema.SessionAborted {
+		t.Fatalf("expected session was aborted, got %+v", aborted)
+	}
+	if len(as.order) != 1 {
+		t.Errorf("expected compensate action of committed participant was invoked, got %v", as.order)
+	}
+
+	session, err = srv.GetSessionById(session.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part = session.GetParticipantAt(part.Id); part.State != schema.ParticipantFailed {
+		t.Errorf("expected failed participant kept its state, got %v", part.State)
+	}
+}
+
+// twoPhaseCommit builds the actions of commit with a prepare action, which is failed by prepare-{participantId}
+func (as *actionServer) twoPhaseCommit(i int, part *schema.Participant) *schema.ParticipantCommit {
+	commit := as.commit(i, part)
+	prepareUri := fmt.Sprintf("%v/prepare-%v", as.URL, part.Id)
+	commit.Prepare = &schema.ParticipantAction{Uri: &prepareUri}
+
+	return commit
+}
+
+func TestCommitTwoPhase(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 0)
+
+	session := startSession(t, srv, &schema.SessionOptions{Timeout: 60, Mode: schema.SessionTwoPhaseCommit}, 2, as.twoPhaseCommit)
+
+	committed, err := srv.CommitSession(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if committed.State != schema.SessionCommitted || committed.Decision != schema.DecisionCommit {
+		t.Errorf("expected session committed by decision commit, got %v %v", committed.State, committed.Decision)
+	}
+
+	// every participant is prepared before any of them is completed
+	if len(as.order) != 4 || !strings.HasPrefix(as.order[0], "prepare-") || !strings.HasPrefix(as.order[1], "prepare-") {
+		t.Errorf("expected prepare actions before complete actions, got %v", as.order)
+	}
+
+	session, err = srv.GetSessionById(session.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range session.Participants {
+		if part.PrepareAction == nil || part.PrepareAction.Status != schema.PartActionCompleted {
+			t.Errorf("expected prepare action of participant %v succeeded, got %+v", part.Id, part.PrepareAction)
+		}
+	}
+}
+
+func TestCommitTwoPhaseVotedAbort(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 0)
+
+	session := startSession(t, srv, &schema.SessionOptions{Timeout: 60, Mode: schema.SessionTwoPhaseCommit}, 2, as.twoPhaseCommit)
+	as.failed["prepare-2"] = true
+
+	aborted, err := srv.CommitSession(session.Id)
+	assertAppError(t, err, service.ErrSessionVotedAbort, http.StatusConflict)
+
+	if aborted.State != schema.SessionAborted || aborted.Decision != schema.DecisionAbort {
+		t.Errorf("expected session aborted by decision abort, got %v %v", aborted.State, aborted.Decision)
+	}
+
+	// no participant is completed, every participant is compensated
+	completed := 0
+	for _, id := range as.order {
+		if !strings.HasPrefix(id, "prepare-") {
+			completed++
+		}
+	}
+	if completed != 2 {
+		t.Errorf("expected 2 compensate actions, got %v", as.order)
+	}
+
+	session, err = srv.GetSessionById(session.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range session.Participants {
+		if part.State != schema.ParticipantCompensated {
+			t.Errorf("expected participant %v compensated, got %v", part.Id, part.State)
+		}
+	}
+}
+
+func TestPartialCommitTwoPhaseRequirePrepare(t *testing.T) {
+	srv := newService(t)
+
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 60, Mode: schema.SessionTwoPhaseCommit}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part := schema.NewParticipant()
+	part.SessionId = session.Id
+	part.ClientId = "client"
+	if part, err = srv.JoinSession(session.Id, part); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = srv.PartialCommitSession(session.Id, &schema.ParticipantCommit{Id: &part.Id})
+	assertAppError(t, err, service.ErrPrepareActionRequired, http.StatusBadRequest)
+}
+
+// tccCommit builds the actions of commit with reservation timeout of each participant
+func (as *actionServer) tccCommit(reservationTimeoutMs ...int) commitBuilder {
+	return func(i int, part *schema.Participant) *schema.ParticipantCommit {
+		commit := as.commit(i, part)
+		commit.ReservationTimeoutMs = reservationTimeoutMs[i]
+
+		return commit
+	}
+}
+
+func TestCommitTCC(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 0)
+
+	session := startSession(t, srv, &schema.SessionOptions{Timeout: 60, Mode: schema.SessionTCC}, 2, as.tccCommit(60000, 60000))
+
+	committed, err := srv.CommitSession(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if committed.State != schema.SessionCommitted {
+		t.Errorf("expected session committed, got %v", committed.State)
+	}
+
+	// confirmed participants no longer hold reservations
+	session, err = srv.GetSessionById(session.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelAt := service.ReservationCancelAt(session); cancelAt != nil {
+		t.Errorf("expected no reservation, got cancel at %v", cancelAt)
+	}
+}
+
+func TestPartialCommitTCCRequireReservation(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 0)
+
+	session := startSession(t, srv, &schema.SessionOptions{Timeout: 60, Mode: schema.SessionTCC}, 0, as.tccCommit())
+
+	part := schema.NewParticipant()
+	part.SessionId = session.Id
+	part.ClientId = "client"
+	part, err := srv.JoinSession(session.Id, part)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri := fmt.Sprintf("%v/%v", as.URL, part.Id)
+	_, err = srv.PartialCommitSession(session.Id, &schema.ParticipantCommit{
+		Id:         &part.Id,
+		Complete:   &schema.ParticipantAction{Uri: &uri},
+		Compensate: &schema.ParticipantAction{Uri: &uri},
+	})
+	assertAppError(t, err, service.ErrReservationRequired, http.StatusBadRequest)
+}
+
+func TestCancelExpiringReservations(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 0)
+
+	session := startSession(t, srv, &schema.SessionOptions{Timeout: 60, Mode: schema.SessionTCC}, 2, as.tccCommit(60000, 100))
+
+	session, err := srv.GetSessionById(session.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelAt := service.ReservationCancelAt(session)
+	if cancelAt == nil || !cancelAt.Equal(*session.Participants[1].ReservationExpiredAt) {
+		t.Fatalf("expected cancel at the earliest reservation %v, got %v", session.Participants[1].ReservationExpiredAt, cancelAt)
+	}
+
+	// not expiring yet
+	next, aborted, err := srv.CancelExpiringReservations(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aborted != nil || next == nil || !next.Equal(*cancelAt) {
+		t.Errorf("expected next cancel at %v, got %v %v", cancelAt, next, aborted)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	next, aborted, err = srv.CancelExpiringReservations(session.Id)
+	assertAppError(t, err, service.ErrReservationExpiring, http.StatusConflict)
+	if next != nil || aborted == nil || aborted.State != schema.SessionAborted {
+		t.Fatalf("expected session aborted, got %v %+v", next, aborted)
+	}
+	if len(as.order) != 2 {
+		t.Errorf("expected every reservation cancelled, got %v", as.order)
+	}
+}
+
+func TestCommitTCCExpiredReservation(t *testing.T) {
+	srv := newService(t)
+	as := newActionServer(t, 0)
+
+	session := startSession(t, srv, &schema.SessionOptions{Timeout: 60, Mode: schema.SessionTCC}, 2, as.tccCommit(60000, 50))
+
+	time.Sleep(100 * time.Millisecond)
+
+	aborted, err := srv.CommitSession(session.Id)
+	assertAppError(t, err, service.ErrReservationExpiring, http.StatusConflict)
+	if aborted == nil || aborted.State != schema.SessionAborted {
+		t.Errorf("expected session aborted instead of confirming expired reservation, got %+v", aborted)
+	}
+}
@@ -7,13 +7,17 @@ import (
 )
 
 type Service struct {
-	s store.Interface
-	l *logrus.Entry
+	s       store.Interface
+	l       *logrus.Entry
+	waiters *lockWaitQueue
+	events  *eventBus
 }
 
 func NewService(s store.Interface) *Service {
 	return &Service{
-		s: s,
+		s:       s,
+		waiters: newLockWaitQueue(),
+		events:  newEventBus(),
 		l: common.Logger().WithFields(logrus.Fields{
 			"pkg": "service",
 		}),
@@ -2,18 +2,34 @@ package service
 
 import (
 	"errors"
+	"math"
+	"net/http"
 	"time"
 
 	"github.com/barrydevp/transcoorditor/pkg/exception"
 	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/spf13/viper"
 )
 
 var (
 	ErrSessionNotFound      = exception.AppNotFoundf("session was not found in storage")
 	ErrSessionNotExpiredYet = exception.AppUnprocessableEntityf("session not expired yet")
 	ErrSessionMaximumRetry  = exception.AppGonef("session maximum retries")
+	ErrSessionNotRetryYet   = exception.AppUnprocessableEntityf("session is not due to retry yet")
+	ErrSessionLockLost      = exception.AppGonef("session lock has been expired or released")
+
+	ErrSessionVotedAbort     = errors.New("some participant voted abort, session was aborted")
+	ErrPrepareActionRequired = errors.New("prepare action is required in two-phase commit session")
+
+	ErrReservationRequired = errors.New("reservation timeout, complete and compensate actions are required in TCC session")
+	ErrReservationExpiring = errors.New("some reservation is about to expire, session was aborted")
 )
 
+// reservationCancelMargin is how long before its expiry a reservation of TCC session is cancelled
+func reservationCancelMargin() time.Duration {
+	return time.Duration(viper.GetInt("TCC_CANCEL_MARGIN_MS")) * time.Millisecond
+}
+
 func (srv *Service) findSessionById(id string) (*schema.Session, error) {
 	doc, err := srv.s.Session().FindById(id)
 	if err != nil {
@@ -97,12 +113,16 @@ func (srv *Service) StartSession(s *schema.Session) (ss *schema.Session, err err
 	// 	return util.NewError("session has already started, state: %v", s.State)
 	// }
 
-	var lockEnt *schema.LockEntry
-	if s.LockKey != nil {
-		lockEnt, err = srv.AcquireLock(*s.LockKey, s.Id, time.Minute*30)
+	lockKeys := s.GetLockKeys()
+	if len(lockKeys) > 0 {
+		lockWait := time.Duration(s.LockWaitTimeoutMs) * time.Millisecond
+		lockEnts, err := srv.AcquireLocksWait(lockKeys, s.Id, s.GetLockMode(), SessionLockDuration, lockWait)
 		if err != nil {
 			return nil, err
 		}
+
+		// all keys are saved in a single command, so they share the same token
+		s.LockToken = lockEnts[0].Token
 	}
 
 	now := time.Now()
@@ -112,17 +132,68 @@ func (srv *Service) StartSession(s *schema.Session) (ss *schema.Session, err err
 	s.UpdatedAt = &now
 
 	if err := srv.s.Session().Save(s); err != nil {
-		if lockEnt != nil {
-			if err1 := srv.ReleaseLock0(lockEnt); err1 != nil {
+		if len(lockKeys) > 0 {
+			if err1 := srv.ReleaseLocks(lockKeys, s.Id); err1 != nil {
 				srv.l.Error("release lock when start session failed", err1)
 			}
 		}
 		return nil, exception.Errorf("failed to save session: %w", err)
 	}
+	srv.emitStateChanged(s)
 
 	return s, nil
 }
 
+// KeepAliveSession extends the session deadline to timeout seconds from now, capped by SESSION_KEEPALIVE_MAX_TIMEOUT.
+// The deadline is never shortened, session locks are extended to outlive the new deadline
+func (srv *Service) KeepAliveSession(id string, timeout int) (*schema.Session, error) {
+	session, err := srv.findSessionById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.CheckSessionActive(); err != nil {
+		return nil, exception.AppPreconditionFailed(err)
+	}
+
+	if maxTimeout := viper.GetInt("SESSION_KEEPALIVE_MAX_TIMEOUT"); maxTimeout > 0 && timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+
+	now := time.Now()
+	deadline := now.Add(time.Second * time.Duration(timeout))
+
+	for _, key := range session.GetLockKeys() {
+		if _, err := srv.ExtendLockUntil(key, session.Id, deadline.Add(SessionLockDuration)); err != nil {
+			var appErr *exception.AppError
+			if errors.As(err, &appErr) && (appErr.Status() == http.StatusNotFound || appErr.Status() == http.StatusGone) {
+				return nil, ErrSessionLockLost
+			}
+
+			return nil, err
+		}
+	}
+
+	if !deadline.After(session.TimedoutAt()) {
+		return session, nil
+	}
+
+	// timeout is counted from StartedAt in seconds, round up to not shorten the requested deadline
+	newTimeout := int(math.Ceil(deadline.Sub(*session.StartedAt).Seconds()))
+	session, err = srv.s.Session().UpdateById(id, &schema.SessionUpdate{
+		Timeout:   &newTimeout,
+		UpdatedAt: &now,
+	})
+	if err != nil {
+		return nil, exception.Errorf("failed to keep session alive: %w", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
 func (srv *Service) JoinSession(sessionId string, part *schema.Participant) (*schema.Participant, error) {
 	session, err := srv.findSessionById(sessionId)
 	if err != nil {
@@ -155,8 +226,9 @@ func (srv *Service) JoinSession(sessionId string, part *schema.Participant) (*sc
 	// @TODO: wrap in transaction
 	// part.SessionId = s.Id
 	part.Id = partNum + 1
+	part.RenewLease()
 	if err := srv.s.Participant().Save(part); err != nil {
-		return nil, exception.Errorf("failed to save participant", err)
+		return nil, exception.Errorf("failed to save participant: %w", err)
 	}
 
 	// first participant in session, change session State
@@ -165,6 +237,7 @@ func (srv *Service) JoinSession(sessionId string, part *schema.Participant) (*sc
 		if _, err := srv.s.Session().UpdateById(sessionId, &schema.SessionUpdate{State: &session.State}); err != nil {
 			return nil, err
 		}
+		srv.emitStateChanged(session)
 	}
 
 	return part, nil
@@ -193,8 +266,26 @@ func (srv *Service) PartialCommitSession(sessionId string, partCommit *schema.Pa
 		return nil, exception.AppPreconditionFailedf("this participant has already committed, current state: %v", part.State)
 	}
 
+	if session.IsTwoPhaseCommit() && partCommit.Prepare == nil {
+		return nil, exception.AppBadRequest(ErrPrepareActionRequired)
+	}
+
+	if session.IsTCC() && (partCommit.ReservationTimeoutMs <= 0 || partCommit.Complete == nil || partCommit.Compensate == nil) {
+		return nil, exception.AppBadRequest(ErrReservationRequired)
+	}
+
 	part.State = schema.ParticipantCommitted
 
+	// reject malformed actions early, instead of failing on commit/abort
+	for _, action := range []*schema.ParticipantAction{partCommit.Compensate, partCommit.Complete, partCommit.Prepare} {
+		if action == nil {
+			continue
+		}
+		if err := action.ValidateAction(); err != nil {
+			return nil, exception.AppBadRequest(err)
+		}
+	}
+
 	if partCommit.Compensate != nil {
 		partCommit.Compensate.Status = schema.PartActionCreated
 		partCommit.Compensate.InvokedCount = 0
@@ -203,11 +294,21 @@ func (srv *Service) PartialCommitSession(sessionId string, partCommit *schema.Pa
 		partCommit.Complete.Status = schema.PartActionCreated
 		partCommit.Complete.InvokedCount = 0
 	}
+	if partCommit.Prepare != nil {
+		partCommit.Prepare.Status = schema.PartActionCreated
+		partCommit.Prepare.InvokedCount = 0
+	}
 
 	partUpdate := &schema.ParticipantUpdate{
 		State:            &part.State,
 		CompensateAction: partCommit.Compensate,
 		CompleteAction:   partCommit.Complete,
+		PrepareAction:    partCommit.Prepare,
+	}
+
+	if session.IsTCC() {
+		reservationExpiredAt := time.Now().Add(time.Duration(partCommit.ReservationTimeoutMs) * time.Millisecond)
+		partUpdate.ReservationExpiredAt = &reservationExpiredAt
 	}
 
 	// @TODO: wrap in transaction
@@ -268,6 +369,7 @@ func (srv *Service) endSession(session *schema.Session, act EndSessionAct) (*sch
 		if _, err = srv.s.Session().UpdateById(session.Id, &schema.SessionUpdate{State: &session.State}); err != nil {
 			return nil, err
 		}
+		srv.emitStateChanged(session)
 
 		// handle participant action
 		errs := srv.handleParticipantActions(session, compensate)
@@ -279,6 +381,13 @@ func (srv *Service) endSession(session *schema.Session, act EndSessionAct) (*sch
 			apiErr.Detail = session
 			err = apiErr
 			session.Retries++
+
+			// schedule next retry by session's retry policy
+			if !session.IsMaximumRetry() {
+				nextRetryAt := time.Now().Add(session.GetRetryPolicy().NextDelay(session.Retries))
+				session.NextRetryAt = &nextRetryAt
+				update.NextRetryAt = session.NextRetryAt
+			}
 		} else {
 			session.State = endOKState
 		}
@@ -300,10 +409,11 @@ func (srv *Service) endSession(session *schema.Session, act EndSessionAct) (*sch
 	if _, err := srv.s.Session().UpdateById(session.Id, update); err != nil {
 		return nil, err
 	}
+	srv.emitStateChanged(session)
 
-	// release lock if has
-	if session.LockKey != nil {
-		if err1 := srv.ReleaseLock(*session.LockKey, session.Id); err1 != nil {
+	// release locks if has
+	if lockKeys := session.GetLockKeys(); len(lockKeys) > 0 {
+		if err1 := srv.ReleaseLocks(lockKeys, session.Id); err1 != nil {
 			srv.l.Error("release lock when end session failed", err1)
 		}
 	}
@@ -326,9 +436,119 @@ func (srv *Service) CommitSession(id string) (*schema.Session, error) {
 		return nil, exception.AppPreconditionFailed(err)
 	}
 
+	if session.IsTwoPhaseCommit() {
+		return srv.commitTwoPhase(session)
+	}
+
+	// confirm is only safe when every reservation outlives it, otherwise all of them are cancelled
+	if session.IsTCC() && session.State != schema.SessionCommitFailed {
+		if cancelAt := ReservationCancelAt(session); cancelAt != nil && !cancelAt.After(time.Now()) {
+			return srv.abortExpiringReservations(session)
+		}
+	}
+
 	return srv.endSession(session, Commit)
 }
 
+// commitTwoPhase collects votes by prepare actions, then completes on unanimous yes or compensates on any no.
+// The decision is persisted before phase two, so recovery and retry follow it
+func (srv *Service) commitTwoPhase(session *schema.Session) (*schema.Session, error) {
+	// decision has been made by the previous attempt, eg: retry of commit-failed session
+	if session.Decision == "" {
+		session.State = schema.SessionPreparing
+		if _, err := srv.s.Session().UpdateById(session.Id, &schema.SessionUpdate{State: &session.State}); err != nil {
+			return nil, err
+		}
+		srv.emitStateChanged(session)
+
+		session.Decision = schema.DecisionCommit
+		session.State = schema.SessionCommitting
+		if errs := srv.handlePrepareActions(session); len(errs) > 0 {
+			session.Decision = schema.DecisionAbort
+			session.State = schema.SessionAborting
+			session.Errors = errs
+		}
+
+		if _, err := srv.s.Session().UpdateById(session.Id, &schema.SessionUpdate{
+			State:    &session.State,
+			Decision: &session.Decision,
+			Errors:   &session.Errors,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if session.Decision == schema.DecisionCommit {
+		return srv.endSession(session, Commit)
+	}
+
+	session, err := srv.endSession(session, Abort)
+	if err != nil {
+		return session, err
+	}
+
+	apiErr := exception.AppConflict(ErrSessionVotedAbort)
+	// inject detail
+	apiErr.Detail = session
+
+	return session, apiErr
+}
+
+// ReservationCancelAt returns when reservations of TCC session must be cancelled if it is not committed yet,
+// nil if the session holds no reservation
+func ReservationCancelAt(session *schema.Session) *time.Time {
+	if !session.IsTCC() {
+		return nil
+	}
+
+	expiredAt := session.EarliestReservationExpiredAt()
+	if expiredAt == nil {
+		return nil
+	}
+
+	cancelAt := expiredAt.Add(-reservationCancelMargin())
+
+	return &cancelAt
+}
+
+// CancelExpiringReservations aborts the active TCC session if its earliest reservation is about to expire.
+// The next cancel time is returned if the session still holds reservations, the session is returned if it was aborted
+func (srv *Service) CancelExpiringReservations(id string) (*time.Time, *schema.Session, error) {
+	session, err := srv.GetSessionById(id, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// session has been ended by itself, committed reservations must be confirmed by retry instead
+	if err := session.CheckSessionActive(); err != nil {
+		return nil, nil, nil
+	}
+
+	cancelAt := ReservationCancelAt(session)
+	if cancelAt == nil || cancelAt.After(time.Now()) {
+		return cancelAt, nil, nil
+	}
+
+	session, err = srv.abortExpiringReservations(session)
+
+	return nil, session, err
+}
+
+func (srv *Service) abortExpiringReservations(session *schema.Session) (*schema.Session, error) {
+	srv.l.Warnf("reservations of session %v are about to expire, cancelling", session.Id)
+
+	session, err := srv.endSession(session, Abort)
+	if err != nil {
+		return session, err
+	}
+
+	apiErr := exception.AppConflict(ErrReservationExpiring)
+	// inject detail
+	apiErr.Detail = session
+
+	return session, apiErr
+}
+
 func (srv *Service) AbortSession(id string) (*schema.Session, error) {
 	session, err := srv.GetSessionById(id, true)
 	if err != nil {
@@ -383,6 +603,9 @@ func (srv *Service) TerminateSession(id string) (*schema.Session, error) {
 
 func getRecoveryAct(session *schema.Session) EndSessionAct {
 	switch session.State {
+	case schema.SessionPreparing:
+		// commit decision was not persisted, presume abort
+		return Abort
 	case schema.SessionTerminating:
 		return Terminate
 	case schema.SessionCommitting:
@@ -404,7 +627,50 @@ func (srv *Service) RecoverySession(id string) (*schema.Session, error) {
 	return srv.endSession(session, getRecoveryAct(session))
 }
 
+func getRetryAct(session *schema.Session) EndSessionAct {
+	switch session.State {
+	case schema.SessionTerminateFailed:
+		return Terminate
+	case schema.SessionCommitFailed:
+		return Commit
+	case schema.SessionAbortFailed:
+		return Abort
+	default:
+		return Noop
+	}
+}
+
+// RetrySession retries the failed commit/abort/terminate of session when its NextRetryAt is due
+func (srv *Service) RetrySession(id string) (*schema.Session, error) {
+	srv.l.Info("Retry session: ", id)
+	session, err := srv.GetSessionById(id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	act := getRetryAct(session)
+	if act == Noop || session.NextRetryAt == nil {
+		// session has been ended or is not scheduled to retry
+		return session, nil
+	}
+
+	if session.IsMaximumRetry() {
+		return session, ErrSessionMaximumRetry
+	}
+
+	if time.Now().Before(*session.NextRetryAt) {
+		return session, ErrSessionNotRetryYet
+	}
+
+	return srv.endSession(session, act)
+}
+
 func (srv *Service) GetAllUnFinishedSession() ([]*schema.Session, error) {
 
 	return srv.s.Session().FindAllUnfinished()
 }
+
+func (srv *Service) GetAllInProcessingSession() ([]*schema.Session, error) {
+
+	return srv.s.Session().FindAllInProcessing()
+}
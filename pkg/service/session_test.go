@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/exception"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/service"
+	"github.com/spf13/viper"
+)
+
+func TestKeepAliveSession(t *testing.T) {
+	srv := newService(t)
+
+	key := "key"
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 10, LockKey: &key}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kept, err := srv.KeepAliveSession(session.Id, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept.Timeout < 120 || kept.Timeout > 121 {
+		t.Errorf("expected timeout about 120, got %v", kept.Timeout)
+	}
+
+	// deadline is never shortened
+	kept, err = srv.KeepAliveSession(session.Id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept.Timeout < 120 {
+		t.Errorf("expected timeout was not shortened, got %v", kept.Timeout)
+	}
+
+	lockEnt, err := srv.GetLock(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lockEnt.ExpiredAt.Before(kept.TimedoutAt()) {
+		t.Errorf("expected lock outlives session deadline %v, got %v", kept.TimedoutAt(), lockEnt.ExpiredAt)
+	}
+}
+
+func TestKeepAliveSessionMaxTimeout(t *testing.T) {
+	srv := newService(t)
+
+	viper.Set("SESSION_KEEPALIVE_MAX_TIMEOUT", 60)
+	t.Cleanup(func() {
+		viper.Set("SESSION_KEEPALIVE_MAX_TIMEOUT", nil)
+	})
+
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 10}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kept, err := srv.KeepAliveSession(session.Id, 3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept.Timeout < 60 || kept.Timeout > 61 {
+		t.Errorf("expected timeout capped at 60, got %v", kept.Timeout)
+	}
+}
+
+func TestKeepAliveEndedSession(t *testing.T) {
+	srv := newService(t)
+
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 10}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AbortSession(session.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = srv.KeepAliveSession(session.Id, 60)
+	var appErr *exception.AppError
+	if !errors.As(err, &appErr) || appErr.Status() != http.StatusPreconditionFailed {
+		t.Errorf("expected status %v, got %v", http.StatusPreconditionFailed, err)
+	}
+
+	_, err = srv.KeepAliveSession("missing", 60)
+	assertAppError(t, err, service.ErrSessionNotFound, http.StatusNotFound)
+}
+
+func TestKeepAliveSessionLockLost(t *testing.T) {
+	srv := newService(t)
+
+	key := "key"
+	session, err := srv.StartSession(schema.NewSession(&schema.SessionOptions{Timeout: 10, LockKey: &key}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.ReleaseLock(key, session.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = srv.KeepAliveSession(session.Id, 60)
+	assertAppError(t, err, service.ErrSessionLockLost, http.StatusGone)
+
+	kept, err := srv.GetSessionById(session.Id, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept.Timeout != 10 || !kept.TimedoutAt().Before(time.Now().Add(time.Minute)) {
+		t.Errorf("expected timeout was not extended, got %v", kept.Timeout)
+	}
+}
@@ -15,6 +15,12 @@ var logger = common.Logger().WithFields(logrus.Fields{
 	"pkg": "store/boltdb",
 })
 
+const (
+	sessionCollection   = "session"
+	lockTableCollection = "locktable"
+	replsetCollection   = "replset"
+)
+
 type baseRepo struct {
 	db *bbolt.DB
 }
@@ -32,6 +38,17 @@ func (b *baseRepo) initCollection(name string) error {
 	return err
 }
 
+// resetCollection drop all keys of the collection, it must be called inside an exec transaction
+func (b *baseRepo) resetCollection(tx *txn, name string) error {
+	if err := tx.tx.DeleteBucket([]byte(name)); err != nil && err != bbolt.ErrBucketNotFound {
+		return err
+	}
+
+	_, err := tx.tx.CreateBucket([]byte(name))
+
+	return err
+}
+
 func (b *baseRepo) read(fn func(tx *txn) error) error {
 	return b.db.View(func(tx *bbolt.Tx) error {
 		return fn(&txn{
@@ -104,7 +121,7 @@ func (s *boltdbBackend) Close() {
 	}
 
 	if err := s.db.Close(); err != nil {
-		logger.Error("Oops... Cannot disconnect BoltDB! Reason: %v", err)
+		logger.Errorf("Oops... Cannot disconnect BoltDB! Reason: %v", err)
 
 		return
 	}
@@ -1,6 +1,7 @@
 package boltdb
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/barrydevp/transcoorditor/pkg/schema"
@@ -13,7 +14,7 @@ type lockTableRepo struct {
 }
 
 func NewLockTable(b *baseRepo) store.LockTable {
-	name := "locktable"
+	name := lockTableCollection
 	err := b.initCollection(name)
 	if err != nil {
 		panic(fmt.Sprintf("cannot create bucket %s: %v", name, err))
@@ -47,6 +48,22 @@ func (s *lockTableRepo) Save(lockEnt *schema.LockEntry) error {
 	})
 }
 
+func (s *lockTableRepo) SaveMany(lockEnts []*schema.LockEntry) error {
+	return s.exec(func(tx *txn) error {
+		col := tx.collection(s.name)
+
+		for _, lockEnt := range lockEnts {
+			clone := *lockEnt
+
+			if err := col.Put(clone.Key, clone); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func (s *lockTableRepo) Update(lockEnt *schema.LockEntry) error {
 	err := s.exec(func(tx *txn) error {
 		col := tx.collection(s.name)
@@ -70,6 +87,7 @@ func (s *lockTableRepo) Update(lockEnt *schema.LockEntry) error {
 		// }
 
 		doc.ExpiredAt = lockEnt.ExpiredAt
+		doc.Holders = lockEnt.Holders
 
 		return col.Put(lockEnt.Key, doc)
 	})
@@ -115,8 +133,8 @@ func (s *lockTableRepo) FindWithOwner(key string, owner string) (*schema.LockEnt
 			return err
 		}
 
-		if doc.Owner != owner {
-			return nil
+		if !doc.HasOwner(owner) {
+			doc = nil
 		}
 
 		return nil
@@ -128,6 +146,31 @@ func (s *lockTableRepo) FindWithOwner(key string, owner string) (*schema.LockEnt
 	return doc, nil
 }
 
+func (s *lockTableRepo) FindAll() ([]*schema.LockEntry, error) {
+	var results []*schema.LockEntry
+
+	err := s.read(func(tx *txn) error {
+		col := tx.collection(s.name)
+
+		c := col.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			doc := &schema.LockEntry{}
+			err := json.Unmarshal(v, doc)
+			if err != nil {
+				return err
+			}
+			results = append(results, doc)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func (s *lockTableRepo) Delete(lockEnt *schema.LockEntry) error {
 	err := s.exec(func(tx *txn) error {
 		col := tx.collection(s.name)
@@ -158,33 +201,45 @@ func (s *lockTableRepo) Delete(lockEnt *schema.LockEntry) error {
 	return nil
 }
 
-// TBD
+// DeleteByOwner releases all locks held by owner, shared lock is only deleted when its last holder is released
 func (s *lockTableRepo) DeleteByOwner(owner string) (int64, error) {
-	// err := s.exec(func(tx *txn) error {
-	// 	col := tx.collection(s.name)
-	//
-	// 	doc := &schema.LockEntry{}
-	// 	_doc, err := col.Get(lockEnt.Key, doc)
-	// 	if err != nil || _doc == nil {
-	// 		doc = nil
-	// 		return err
-	// 	}
-	//
-	// 	if doc.Owner != lockEnt.Owner {
-	// 		return nil
-	// 		// return store.ErrLockNotOwner
-	// 	}
-	//
-	// 	if err := col.Delete(lockEnt.Key); err != nil {
-	// 		return err
-	// 	}
-	//
-	// 	return nil
-	// })
-	//
-	// if err != nil {
-	// 	return err
-	// }
-
-	return 0, nil
+	deletedCount := int64(0)
+
+	err := s.exec(func(tx *txn) error {
+		col := tx.collection(s.name)
+
+		var docs []*schema.LockEntry
+		c := col.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			doc := &schema.LockEntry{}
+			err := json.Unmarshal(v, doc)
+			if err != nil {
+				return err
+			}
+
+			if doc.ReleaseOwner(owner) {
+				docs = append(docs, doc)
+			}
+		}
+
+		for _, doc := range docs {
+			var err error
+			if doc.IsHeld() {
+				err = col.Put(doc.Key, doc)
+			} else {
+				err = col.Delete(doc.Key)
+			}
+			if err != nil {
+				return err
+			}
+			deletedCount++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deletedCount, nil
 }
@@ -3,6 +3,7 @@ package boltdb
 import (
 	// "context"
 	"fmt"
+	"time"
 
 	"github.com/barrydevp/transcoorditor/pkg/schema"
 	"github.com/barrydevp/transcoorditor/pkg/store"
@@ -14,7 +15,7 @@ type participantRepo struct {
 }
 
 func NewParticipant(b *baseRepo) store.Participant {
-	name := "session"
+	name := sessionCollection
 	err := b.initCollection(name)
 	if err != nil {
 		panic(fmt.Sprintf("cannot create bucket %s: %v", name, err))
@@ -44,7 +45,7 @@ func (s *participantRepo) getSession(col *collection, id string) (*schema.Sessio
 		return nil, err
 	}
 	if _doc == nil {
-		return nil, store.ErrSessionNotFound
+		return nil, nil
 	}
 
 	return doc, nil
@@ -60,7 +61,7 @@ func (s *participantRepo) Save(part *schema.Participant) error {
 		}
 
 		if doc == nil {
-
+			return store.ErrSessionNotFound
 		}
 
 		doc.Participants = append(doc.Participants, part)
@@ -76,7 +77,7 @@ func (s *participantRepo) PutBySessionAndId(sessionId string, id int64, schemaUp
 		col := tx.collection(s.name)
 
 		session, err := s.getSession(col, sessionId)
-		if err != nil {
+		if err != nil || session == nil {
 			return err
 		}
 
@@ -117,13 +118,19 @@ func (s *participantRepo) PutBySessionAndId(sessionId string, id int64, schemaUp
 			doc.CompleteAction = schemaUpdate.CompleteAction
 		}
 
+		if schemaUpdate.PrepareAction != nil {
+			needUpdate = true
+			doc.PrepareAction = schemaUpdate.PrepareAction
+		}
+
 		// no changes
 		if !needUpdate {
 			return nil
 		}
 
 		if schemaUpdate.UpdatedAt == nil {
-			doc.UpdatedAt = schemaUpdate.UpdatedAt
+			now := time.Now()
+			doc.UpdatedAt = &now
 		}
 
 		return col.Put(sessionId, session)
@@ -142,7 +149,7 @@ func (s *participantRepo) FindBySessionAndId(sessionId string, id int64) (*schem
 		col := tx.collection(s.name)
 
 		session, err := s.getSession(col, sessionId)
-		if err != nil {
+		if err != nil || session == nil {
 			return err
 		}
 
@@ -164,7 +171,7 @@ func (s *participantRepo) FindBySessionId(sessionId string) ([]*schema.Participa
 		col := tx.collection(s.name)
 
 		session, err := s.getSession(col, sessionId)
-		if err != nil {
+		if err != nil || session == nil {
 			return err
 		}
 
@@ -201,7 +208,7 @@ func (s *participantRepo) UpdateBySessionAndId(sessionId string, id int64, schem
 		col := tx.collection(s.name)
 
 		session, err := s.getSession(col, sessionId)
-		if err != nil {
+		if err != nil || session == nil {
 			return err
 		}
 
@@ -232,13 +239,29 @@ func (s *participantRepo) UpdateBySessionAndId(sessionId string, id int64, schem
 			doc.CompleteAction = schemaUpdate.CompleteAction
 		}
 
+		if schemaUpdate.PrepareAction != nil {
+			needUpdate = true
+			doc.PrepareAction = schemaUpdate.PrepareAction
+		}
+
+		if schemaUpdate.LeaseExpiredAt != nil {
+			needUpdate = true
+			doc.LeaseExpiredAt = schemaUpdate.LeaseExpiredAt
+		}
+
+		if schemaUpdate.ReservationExpiredAt != nil {
+			needUpdate = true
+			doc.ReservationExpiredAt = schemaUpdate.ReservationExpiredAt
+		}
+
 		// no changes
 		if !needUpdate {
 			return nil
 		}
 
 		if schemaUpdate.UpdatedAt == nil {
-			doc.UpdatedAt = schemaUpdate.UpdatedAt
+			now := time.Now()
+			doc.UpdatedAt = &now
 		}
 
 		return col.Put(sessionId, session)
@@ -266,7 +289,7 @@ func (s *participantRepo) DeleteBySessionId(sessionId string) (int64, error) {
 		col := tx.collection(s.name)
 
 		session, err := s.getSession(col, sessionId)
-		if err != nil {
+		if err != nil || session == nil {
 			return nil
 			// return err
 		}
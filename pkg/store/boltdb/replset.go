@@ -17,7 +17,7 @@ type replsetRepo struct {
 }
 
 func NewReplset(b *baseRepo) store.Replset {
-	name := "replset"
+	name := replsetCollection
 	err := b.initCollection(name)
 	if err != nil {
 		panic(fmt.Sprintf("cannot create bucket %s: %v", name, err))
@@ -60,3 +60,39 @@ func (s *replsetRepo) GetLastLog() (*raft.Log, error) {
 
 	return doc, nil
 }
+
+func (s *replsetRepo) Restore(state *store.State) error {
+	// participants are embedded into their session document
+	sessions, err := state.EmbeddedSessions()
+	if err != nil {
+		return err
+	}
+
+	return s.exec(func(tx *txn) error {
+		for _, name := range []string{sessionCollection, lockTableCollection, s.name} {
+			if err := s.resetCollection(tx, name); err != nil {
+				return err
+			}
+		}
+
+		sessionCol := tx.collection(sessionCollection)
+		for id, session := range sessions {
+			if err := sessionCol.Put(id, session); err != nil {
+				return err
+			}
+		}
+
+		lockCol := tx.collection(lockTableCollection)
+		for _, lockEnt := range state.Locks {
+			if err := lockCol.Put(lockEnt.Key, lockEnt); err != nil {
+				return err
+			}
+		}
+
+		if state.LastLog != nil {
+			return tx.collection(s.name).Put(LastLogKey, state.LastLog)
+		}
+
+		return nil
+	})
+}
@@ -3,6 +3,7 @@ package boltdb
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/barrydevp/transcoorditor/pkg/schema"
 	"github.com/barrydevp/transcoorditor/pkg/store"
@@ -14,7 +15,7 @@ type sessionRepo struct {
 }
 
 func NewSession(b *baseRepo) store.Session {
-	name := "session"
+	name := sessionCollection
 	err := b.initCollection(name)
 	if err != nil {
 		panic(fmt.Sprintf("cannot create bucket %s: %v", name, err))
@@ -87,7 +88,8 @@ func (s *sessionRepo) PutById(id string, schemaUpdate *schema.Session) (*schema.
 		}
 
 		if schemaUpdate.UpdatedAt == nil {
-			doc.UpdatedAt = schemaUpdate.UpdatedAt
+			now := time.Now()
+			doc.UpdatedAt = &now
 		}
 
 		return col.Put(id, doc)
@@ -162,7 +164,63 @@ func (s *sessionRepo) FindAllUnfinished() ([]*schema.Session, error) {
 				return err
 			}
 
-			if !doc.IsFinished() {
+			if doc.IsUnfinished() {
+				results = append(results, doc)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *sessionRepo) FindAllInProcessing() ([]*schema.Session, error) {
+	var results []*schema.Session
+
+	err := s.read(func(tx *txn) error {
+		col := tx.collection(s.name)
+
+		c := col.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			doc := &schema.Session{}
+			err := json.Unmarshal(v, doc)
+			if err != nil {
+				return err
+			}
+
+			if doc.CheckInProcessing() != nil {
+				results = append(results, doc)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *sessionRepo) FindAllNotifying() ([]*schema.Session, error) {
+	var results []*schema.Session
+
+	err := s.read(func(tx *txn) error {
+		col := tx.collection(s.name)
+
+		c := col.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			doc := &schema.Session{}
+			err := json.Unmarshal(v, doc)
+			if err != nil {
+				return err
+			}
+
+			if doc.IsNotifying() {
 				results = append(results, doc)
 			}
 		}
@@ -226,13 +284,29 @@ func (s *sessionRepo) UpdateById(id string, schemaUpdate *schema.SessionUpdate)
 			doc.TerminateReason = *schemaUpdate.TerminateReason
 		}
 
+		if schemaUpdate.NextRetryAt != nil {
+			needUpdate = true
+			doc.NextRetryAt = schemaUpdate.NextRetryAt
+		}
+
+		if schemaUpdate.Decision != nil {
+			needUpdate = true
+			doc.Decision = *schemaUpdate.Decision
+		}
+
+		if schemaUpdate.Notifications != nil {
+			needUpdate = true
+			doc.Notifications = *schemaUpdate.Notifications
+		}
+
 		// no changes
 		if !needUpdate {
 			return nil
 		}
 
 		if schemaUpdate.UpdatedAt == nil {
-			doc.UpdatedAt = schemaUpdate.UpdatedAt
+			now := time.Now()
+			doc.UpdatedAt = &now
 		}
 
 		return col.Put(id, doc)
@@ -0,0 +1,25 @@
+package boltdb_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/barrydevp/transcoorditor/pkg/store/boltdb"
+	"github.com/barrydevp/transcoorditor/pkg/store/storetest"
+	"github.com/spf13/viper"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Interface {
+		viper.Set("BOLTDB_PATH", filepath.Join(t.TempDir(), "store.db"))
+
+		s, err := boltdb.NewStore()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(s.Close)
+
+		return s
+	})
+}
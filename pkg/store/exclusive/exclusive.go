@@ -1,6 +1,8 @@
 package exclusive
 
 import (
+	"sort"
+
 	// "github.com/barrydevp/lockey"
 	"github.com/barrydevp/transcoorditor/pkg/store"
 	"github.com/barrydevp/transcoorditor/pkg/util"
@@ -23,6 +25,31 @@ func (s *baseRepo) withLock(key string, exFn exclusiveFunc) {
 	exFn()
 }
 
+// withLocks locks all keys in sorted order to avoid deadlock
+func (s *baseRepo) withLocks(keys []string, exFn exclusiveFunc) {
+	sorted := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		s.rwLocks.Lock(key)
+	}
+
+	defer func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			s.rwLocks.Unlock(sorted[i])
+		}
+	}()
+
+	exFn()
+}
+
 func (s *baseRepo) withRLock(key string, exFn exclusiveFunc) {
 	s.rwLocks.RLock(key)
 
@@ -25,6 +25,18 @@ func (s *lockTableRepo) Save(lockEnt *schema.LockEntry) (err error) {
 	return
 }
 
+func (s *lockTableRepo) SaveMany(lockEnts []*schema.LockEntry) (err error) {
+	keys := make([]string, 0, len(lockEnts))
+	for _, lockEnt := range lockEnts {
+		keys = append(keys, lockEnt.Key)
+	}
+
+	s.withLocks(keys, func() {
+		err = s.s.SaveMany(lockEnts)
+	})
+	return
+}
+
 func (s *lockTableRepo) Update(lockEnt *schema.LockEntry) (err error) {
 	s.withLock(lockEnt.Key, func() {
 		err = s.s.Update(lockEnt)
@@ -40,6 +52,10 @@ func (s *lockTableRepo) FindWithOwner(key string, owner string) (*schema.LockEnt
 	return s.s.FindWithOwner(key, owner)
 }
 
+func (s *lockTableRepo) FindAll() ([]*schema.LockEntry, error) {
+	return s.s.FindAll()
+}
+
 func (s *lockTableRepo) Delete(lockEnt *schema.LockEntry) (err error) {
 	s.withLock(lockEnt.Key, func() {
 		err = s.s.Delete(lockEnt)
@@ -32,3 +32,11 @@ func (s *replsetRepo) GetLastLog() (log *raft.Log, err error) {
 
 	return
 }
+
+func (s *replsetRepo) Restore(state *store.State) (err error) {
+	s.withLock("replset_log", func() {
+		err = s.s.Restore(state)
+	})
+
+	return
+}
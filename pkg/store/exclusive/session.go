@@ -37,6 +37,14 @@ func (s *sessionRepo) FindAllUnfinished() ([]*schema.Session, error) {
 	return s.s.FindAllUnfinished()
 }
 
+func (s *sessionRepo) FindAllInProcessing() ([]*schema.Session, error) {
+	return s.s.FindAllInProcessing()
+}
+
+func (s *sessionRepo) FindAllNotifying() ([]*schema.Session, error) {
+	return s.s.FindAllNotifying()
+}
+
 func (s *sessionRepo) FindById(id string) (session *schema.Session, err error) {
 	s.withLock(id, func() {
 		session, err = s.s.FindById(id)
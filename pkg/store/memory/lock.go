@@ -1,42 +1,192 @@
 package memory
 
 import (
+	"encoding/json"
+
 	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
 )
 
-// memory storage
-// TBD
 type lockTableRepo struct {
-	m map[string]interface{}
+	*baseRepo
+	name string
 }
 
-func NewLockTable() *lockTableRepo {
-
+func NewLockTable(b *baseRepo) store.LockTable {
 	return &lockTableRepo{
-		m: make(map[string]interface{}),
+		baseRepo: b,
+		name:     lockTableCollection,
 	}
 }
 
 func (s *lockTableRepo) Save(lockEnt *schema.LockEntry) error {
-	return nil
+	return s.exec(func() error {
+		col := s.collection(s.name)
+
+		clone := *lockEnt
+
+		return col.Put(clone.Key, clone)
+	})
+}
+
+func (s *lockTableRepo) SaveMany(lockEnts []*schema.LockEntry) error {
+	return s.exec(func() error {
+		col := s.collection(s.name)
+
+		for _, lockEnt := range lockEnts {
+			clone := *lockEnt
+
+			if err := col.Put(clone.Key, clone); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 func (s *lockTableRepo) Update(lockEnt *schema.LockEntry) error {
-	return nil
+	return s.exec(func() error {
+		col := s.collection(s.name)
+
+		doc := &schema.LockEntry{}
+		_doc, err := col.Get(lockEnt.Key, doc)
+		if err != nil {
+			return err
+		}
+
+		if _doc == nil {
+			return store.ErrLockNotFound
+		}
+
+		if lockEnt.Owner != doc.Owner {
+			return store.ErrLockNotOwner
+		}
+
+		doc.ExpiredAt = lockEnt.ExpiredAt
+		doc.Holders = lockEnt.Holders
+
+		return col.Put(lockEnt.Key, doc)
+	})
 }
 
 func (s *lockTableRepo) Find(key string) (*schema.LockEntry, error) {
-	return nil, nil
+	var doc *schema.LockEntry
+
+	err := s.read(func() error {
+		col := s.collection(s.name)
+
+		doc = &schema.LockEntry{}
+		_doc, err := col.Get(key, doc)
+		if err != nil || _doc == nil {
+			doc = nil
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
 }
 
 func (s *lockTableRepo) FindWithOwner(key string, owner string) (*schema.LockEntry, error) {
-	return nil, nil
+	doc, err := s.Find(key)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	if !doc.HasOwner(owner) {
+		return nil, nil
+	}
+
+	return doc, nil
+}
+
+func (s *lockTableRepo) FindAll() ([]*schema.LockEntry, error) {
+	var results []*schema.LockEntry
+
+	err := s.read(func() error {
+		col := s.collection(s.name)
+
+		return col.ForEach(func(key string, v []byte) error {
+			doc := &schema.LockEntry{}
+			err := json.Unmarshal(v, doc)
+			if err != nil {
+				return err
+			}
+			results = append(results, doc)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
 }
 
 func (s *lockTableRepo) Delete(lockEnt *schema.LockEntry) error {
-	return nil
+	return s.exec(func() error {
+		col := s.collection(s.name)
+
+		doc := &schema.LockEntry{}
+		_doc, err := col.Get(lockEnt.Key, doc)
+		if err != nil || _doc == nil {
+			return err
+		}
+
+		if doc.Owner != lockEnt.Owner {
+			return nil
+		}
+
+		return col.Delete(lockEnt.Key)
+	})
 }
 
+// DeleteByOwner releases all locks held by owner, shared lock is only deleted when its last holder is released
 func (s *lockTableRepo) DeleteByOwner(owner string) (int64, error) {
-	return 0, nil
+	deletedCount := int64(0)
+
+	err := s.exec(func() error {
+		col := s.collection(s.name)
+
+		var docs []*schema.LockEntry
+		err := col.ForEach(func(key string, v []byte) error {
+			doc := &schema.LockEntry{}
+			if err := json.Unmarshal(v, doc); err != nil {
+				return err
+			}
+			if doc.ReleaseOwner(owner) {
+				docs = append(docs, doc)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range docs {
+			if doc.IsHeld() {
+				err = col.Put(doc.Key, doc)
+			} else {
+				err = col.Delete(doc.Key)
+			}
+			if err != nil {
+				return err
+			}
+			deletedCount++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deletedCount, nil
 }
@@ -1,18 +1,130 @@
 package memory
 
 import (
+	"encoding/json"
+	"sort"
+	"sync"
+
 	"github.com/barrydevp/transcoorditor/pkg/store"
 )
 
+const (
+	sessionCollection   = "session"
+	lockTableCollection = "locktable"
+	replsetCollection   = "replset"
+)
+
+// collection stores json encoded documents, so the same as boltdb,
+// returned documents never share memory with the stored ones
+type collection struct {
+	m map[string][]byte
+}
+
+func newCollection() *collection {
+	return &collection{
+		m: make(map[string][]byte),
+	}
+}
+
+func (c *collection) Get(key string, dst interface{}) (interface{}, error) {
+	docBuf, ok := c.m[key]
+
+	if !ok {
+		return nil, nil
+	}
+
+	if dst == nil {
+		return docBuf, nil
+	}
+
+	err := json.Unmarshal(docBuf, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return docBuf, nil
+}
+
+func (c *collection) Put(key string, src interface{}) error {
+	docBuf, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	c.m[key] = docBuf
+
+	return nil
+}
+
+func (c *collection) Delete(key string) error {
+	delete(c.m, key)
+
+	return nil
+}
+
+// ForEach iterates over documents in key order
+func (c *collection) ForEach(fn func(key string, v []byte) error) error {
+	keys := make([]string, 0, len(c.m))
+	for k := range c.m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := fn(k, c.m[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type baseRepo struct {
+	mutex       sync.RWMutex
+	collections map[string]*collection
+}
+
+func newBaseRepo() *baseRepo {
+	return &baseRepo{
+		collections: map[string]*collection{
+			sessionCollection:   newCollection(),
+			lockTableCollection: newCollection(),
+			replsetCollection:   newCollection(),
+		},
+	}
+}
+
+// collection must be called inside read or exec
+func (b *baseRepo) collection(name string) *collection {
+	return b.collections[name]
+}
+
+func (b *baseRepo) read(fn func() error) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return fn()
+}
+
+func (b *baseRepo) exec(fn func() error) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return fn()
+}
+
 type memoryBackend struct {
 	*store.Backend
 }
 
 func NewStore() (store.Interface, error) {
+	baseRepo := newBaseRepo()
+
 	backend := &store.Backend{
-		SessionImpl:     NewSession(),
-		ParticipantImpl: NewParticipant(),
-		LockTableImpl: NewLockTable(),
+		SessionImpl:     NewSession(baseRepo),
+		ParticipantImpl: NewParticipant(baseRepo),
+		ReplsetImpl:     NewReplset(baseRepo),
+		LockTableImpl:   NewLockTable(baseRepo),
 	}
 
 	return &memoryBackend{
@@ -1,67 +1,288 @@
 package memory
 
 import (
+	"time"
+
 	"github.com/barrydevp/transcoorditor/pkg/schema"
-	"github.com/barrydevp/transcoorditor/pkg/util"
+	"github.com/barrydevp/transcoorditor/pkg/store"
 )
 
-// memory storage
-// TBD
+// participants are embedded into their session document, the same as boltdb
 type participantRepo struct {
-	m map[int64]interface{}
+	*baseRepo
+	name string
 }
 
-func NewParticipant() *participantRepo {
-
+func NewParticipant(b *baseRepo) store.Participant {
 	return &participantRepo{
-		m: make(map[int64]interface{}),
+		baseRepo: b,
+		name:     sessionCollection,
 	}
 }
 
+func (s *participantRepo) getSession(col *collection, id string) (*schema.Session, error) {
+	doc := &schema.Session{}
+	_doc, err := col.Get(id, doc)
+	if err != nil {
+		return nil, err
+	}
+	if _doc == nil {
+		return nil, nil
+	}
+
+	return doc, nil
+}
+
 func (s *participantRepo) Save(part *schema.Participant) error {
-	s.m[part.Id] = part
+	return s.exec(func() error {
+		col := s.collection(s.name)
+
+		doc, err := s.getSession(col, part.SessionId)
+		if err != nil {
+			return err
+		}
+
+		if doc == nil {
+			return store.ErrSessionNotFound
+		}
 
-	return nil
+		doc.Participants = append(doc.Participants, part)
+
+		return col.Put(doc.Id, doc)
+	})
 }
 
-func (s *participantRepo) PutBySessionAndId(sessionId string, id int64, part *schema.Participant) (*schema.Participant, error) {
-	return nil, nil
+func (s *participantRepo) PutBySessionAndId(sessionId string, id int64, schemaUpdate *schema.Participant) (*schema.Participant, error) {
+	var doc *schema.Participant
+
+	err := s.exec(func() error {
+		col := s.collection(s.name)
+
+		session, err := s.getSession(col, sessionId)
+		if err != nil || session == nil {
+			return err
+		}
+
+		doc = session.GetParticipantAt(id)
+		if doc == nil {
+			return nil
+		}
+
+		needUpdate := false
+
+		if schemaUpdate.State != "" {
+			needUpdate = true
+			doc.State = schemaUpdate.State
+		}
+
+		if schemaUpdate.UpdatedAt != nil {
+			needUpdate = true
+			doc.UpdatedAt = schemaUpdate.UpdatedAt
+		}
+
+		if schemaUpdate.ClientId != "" {
+			needUpdate = true
+			doc.ClientId = schemaUpdate.ClientId
+		}
+
+		if schemaUpdate.RequestId != "" {
+			needUpdate = true
+			doc.RequestId = schemaUpdate.RequestId
+		}
+
+		if schemaUpdate.CompensateAction != nil {
+			needUpdate = true
+			doc.CompensateAction = schemaUpdate.CompensateAction
+		}
+
+		if schemaUpdate.CompleteAction != nil {
+			needUpdate = true
+			doc.CompleteAction = schemaUpdate.CompleteAction
+		}
+
+		if schemaUpdate.PrepareAction != nil {
+			needUpdate = true
+			doc.PrepareAction = schemaUpdate.PrepareAction
+		}
+
+		// no changes
+		if !needUpdate {
+			return nil
+		}
+
+		if schemaUpdate.UpdatedAt == nil {
+			now := time.Now()
+			doc.UpdatedAt = &now
+		}
+
+		return col.Put(sessionId, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
 }
 
 func (s *participantRepo) FindBySessionAndId(sessionId string, id int64) (*schema.Participant, error) {
-	data := s.m[id]
+	var doc *schema.Participant
 
-	if data == nil {
-		return nil, util.Errorf("not found")
-	}
+	err := s.read(func() error {
+		col := s.collection(s.name)
+
+		session, err := s.getSession(col, sessionId)
+		if err != nil || session == nil {
+			return err
+		}
 
-	part, ok := data.(*schema.Participant)
-	if !ok {
-		delete(s.m, id)
+		doc = session.GetParticipantAt(id)
 
-		return nil, util.Errorf("detect unexpected behavior")
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return part, nil
+	return doc, nil
 }
 
-// @TODO
 func (s *participantRepo) FindBySessionId(sessionId string) ([]*schema.Participant, error) {
-	return nil, nil
+	var results []*schema.Participant
+
+	err := s.read(func() error {
+		col := s.collection(s.name)
+
+		session, err := s.getSession(col, sessionId)
+		if err != nil || session == nil {
+			return err
+		}
+
+		results = session.Participants
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
 }
 
-func (s *participantRepo) FindDupInSession(sessionId string, part *schema.Participant) (*schema.Participant, error) {
+func (s *participantRepo) FindDupInSession(sessionId string, reqPart *schema.Participant) (*schema.Participant, error) {
+	allPart, err := s.FindBySessionId(sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, part := range allPart {
+		if part.RequestId == reqPart.RequestId && part.ClientId == reqPart.ClientId {
+			return part, nil
+		}
+	}
+
 	return nil, nil
 }
 
-func (s *participantRepo) UpdateBySessionAndId(sessionId string, id int64, partUpdate *schema.ParticipantUpdate) (*schema.Participant, error) {
-	return nil, nil
+func (s *participantRepo) UpdateBySessionAndId(sessionId string, id int64, schemaUpdate *schema.ParticipantUpdate) (*schema.Participant, error) {
+	var doc *schema.Participant
+
+	err := s.exec(func() error {
+		col := s.collection(s.name)
+
+		session, err := s.getSession(col, sessionId)
+		if err != nil || session == nil {
+			return err
+		}
+
+		doc = session.GetParticipantAt(id)
+		if doc == nil {
+			return nil
+		}
+
+		needUpdate := false
+
+		if schemaUpdate.State != nil {
+			needUpdate = true
+			doc.State = *schemaUpdate.State
+		}
+
+		if schemaUpdate.UpdatedAt != nil {
+			needUpdate = true
+			doc.UpdatedAt = schemaUpdate.UpdatedAt
+		}
+
+		if schemaUpdate.CompensateAction != nil {
+			needUpdate = true
+			doc.CompensateAction = schemaUpdate.CompensateAction
+		}
+
+		if schemaUpdate.CompleteAction != nil {
+			needUpdate = true
+			doc.CompleteAction = schemaUpdate.CompleteAction
+		}
+
+		if schemaUpdate.PrepareAction != nil {
+			needUpdate = true
+			doc.PrepareAction = schemaUpdate.PrepareAction
+		}
+
+		if schemaUpdate.LeaseExpiredAt != nil {
+			needUpdate = true
+			doc.LeaseExpiredAt = schemaUpdate.LeaseExpiredAt
+		}
+
+		if schemaUpdate.ReservationExpiredAt != nil {
+			needUpdate = true
+			doc.ReservationExpiredAt = schemaUpdate.ReservationExpiredAt
+		}
+
+		// no changes
+		if !needUpdate {
+			return nil
+		}
+
+		if schemaUpdate.UpdatedAt == nil {
+			now := time.Now()
+			doc.UpdatedAt = &now
+		}
+
+		return col.Put(sessionId, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
 }
 
 func (s *participantRepo) CountBySessionId(sessionId string) (int64, error) {
-	return 0, nil
+	allPart, err := s.FindBySessionId(sessionId)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(allPart)), nil
 }
 
 func (s *participantRepo) DeleteBySessionId(sessionId string) (int64, error) {
-	return 0, nil
+	deletedCount := 0
+
+	err := s.exec(func() error {
+		col := s.collection(s.name)
+
+		session, err := s.getSession(col, sessionId)
+		if err != nil || session == nil {
+			return nil
+		}
+
+		deletedCount = len(session.Participants)
+		session.Participants = nil
+
+		return col.Put(sessionId, session)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(deletedCount), nil
 }
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/hashicorp/raft"
+)
+
+const (
+	LastLogKey = "replset_last_log"
+)
+
+type replsetRepo struct {
+	*baseRepo
+	name string
+}
+
+func NewReplset(b *baseRepo) store.Replset {
+	return &replsetRepo{
+		baseRepo: b,
+		name:     replsetCollection,
+	}
+}
+
+func (s *replsetRepo) SaveLastLog(log *raft.Log) error {
+	return s.exec(func() error {
+		col := s.collection(s.name)
+
+		clone := *log
+
+		return col.Put(LastLogKey, clone)
+	})
+}
+
+func (s *replsetRepo) GetLastLog() (*raft.Log, error) {
+	var doc *raft.Log
+
+	err := s.read(func() error {
+		col := s.collection(s.name)
+
+		doc = &raft.Log{}
+		_doc, err := col.Get(LastLogKey, doc)
+		if err != nil || _doc == nil {
+			doc = nil
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (s *replsetRepo) Restore(state *store.State) error {
+	// participants are embedded into their session document
+	sessions, err := state.EmbeddedSessions()
+	if err != nil {
+		return err
+	}
+
+	// build the new collections aside, then swap them in at once
+	sessionCol := newCollection()
+	for id, session := range sessions {
+		if err := sessionCol.Put(id, session); err != nil {
+			return err
+		}
+	}
+
+	lockCol := newCollection()
+	for _, lockEnt := range state.Locks {
+		if err := lockCol.Put(lockEnt.Key, lockEnt); err != nil {
+			return err
+		}
+	}
+
+	replsetCol := newCollection()
+	if state.LastLog != nil {
+		if err := replsetCol.Put(LastLogKey, state.LastLog); err != nil {
+			return err
+		}
+	}
+
+	return s.exec(func() error {
+		s.collections[sessionCollection] = sessionCol
+		s.collections[lockTableCollection] = lockCol
+		s.collections[s.name] = replsetCol
+
+		return nil
+	})
+}
@@ -1,62 +1,274 @@
 package memory
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/barrydevp/transcoorditor/pkg/schema"
-	"github.com/barrydevp/transcoorditor/pkg/util"
+	"github.com/barrydevp/transcoorditor/pkg/store"
 )
 
-// memory storage
-// TBD
 type sessionRepo struct {
-	m map[string]interface{}
+	*baseRepo
+	name string
 }
 
-func NewSession() *sessionRepo {
-
+func NewSession(b *baseRepo) store.Session {
 	return &sessionRepo{
-		m: make(map[string]interface{}),
+		baseRepo: b,
+		name:     sessionCollection,
 	}
 }
 
 func (s *sessionRepo) Save(session *schema.Session) error {
-	s.m[session.Id] = session
+	return s.exec(func() error {
+		col := s.collection(s.name)
 
-	return nil
+		clone := *session
+
+		return col.Put(clone.Id, clone)
+	})
 }
 
 func (s *sessionRepo) PutById(id string, schemaUpdate *schema.Session) (*schema.Session, error) {
-	return nil, nil
-}
+	var doc *schema.Session
 
-func (s *sessionRepo) Find(search *schema.SessionSearch) ([]*schema.Session, error) {
-	return nil, nil
-}
+	err := s.exec(func() error {
+		col := s.collection(s.name)
 
-func (s *sessionRepo) FindAllUnfinished() ([]*schema.Session, error) {
-	return nil, nil
+		doc = &schema.Session{}
+		_doc, err := col.Get(id, doc)
+		if err != nil || _doc == nil {
+			doc = nil
+			return err
+		}
+
+		needUpdate := false
+
+		if schemaUpdate.State != "" {
+			needUpdate = true
+			doc.State = schemaUpdate.State
+		}
+
+		if schemaUpdate.Errors != nil {
+			needUpdate = true
+			doc.Errors = schemaUpdate.Errors
+		}
+
+		if schemaUpdate.EndAt != nil {
+			needUpdate = true
+			doc.EndAt = schemaUpdate.EndAt
+		}
+
+		if schemaUpdate.UpdatedAt != nil {
+			needUpdate = true
+			doc.UpdatedAt = schemaUpdate.UpdatedAt
+		}
+
+		if schemaUpdate.Timeout != 0 {
+			needUpdate = true
+			doc.Timeout = schemaUpdate.Timeout
+		}
+
+		if schemaUpdate.Retries != 0 {
+			needUpdate = true
+			doc.Retries = schemaUpdate.Retries
+		}
+
+		// no changes
+		if !needUpdate {
+			return nil
+		}
+
+		if schemaUpdate.UpdatedAt == nil {
+			now := time.Now()
+			doc.UpdatedAt = &now
+		}
+
+		return col.Put(id, doc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
 }
 
 func (s *sessionRepo) FindById(id string) (*schema.Session, error) {
-	data := s.m[id]
+	var doc *schema.Session
 
-	if data == nil {
-		return nil, util.Errorf("not found")
+	err := s.read(func() error {
+		col := s.collection(s.name)
+
+		doc = &schema.Session{}
+		_doc, err := col.Get(id, doc)
+		if err != nil || _doc == nil {
+			doc = nil
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	session, ok := data.(*schema.Session)
-	if !ok {
-		delete(s.m, id)
+	return doc, nil
+}
+
+func (s *sessionRepo) find(match func(doc *schema.Session) bool) ([]*schema.Session, error) {
+	var results []*schema.Session
 
-		return nil, util.Errorf("detect unexpected behavior")
+	err := s.read(func() error {
+		col := s.collection(s.name)
+
+		return col.ForEach(func(key string, v []byte) error {
+			doc := &schema.Session{}
+			err := json.Unmarshal(v, doc)
+			if err != nil {
+				return err
+			}
+
+			if match(doc) {
+				results = append(results, doc)
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return session, nil
+	return results, nil
+}
+
+func (s *sessionRepo) Find(search *schema.SessionSearch) ([]*schema.Session, error) {
+	return s.find(func(doc *schema.Session) bool {
+		return true
+	})
+}
+
+func (s *sessionRepo) FindAllUnfinished() ([]*schema.Session, error) {
+	return s.find(func(doc *schema.Session) bool {
+		return doc.IsUnfinished()
+	})
+}
+
+func (s *sessionRepo) FindAllInProcessing() ([]*schema.Session, error) {
+	return s.find(func(doc *schema.Session) bool {
+		return doc.CheckInProcessing() != nil
+	})
+}
+
+func (s *sessionRepo) FindAllNotifying() ([]*schema.Session, error) {
+	return s.find(func(doc *schema.Session) bool {
+		return doc.IsNotifying()
+	})
 }
 
 func (s *sessionRepo) UpdateById(id string, schemaUpdate *schema.SessionUpdate) (*schema.Session, error) {
-	return nil, nil
+	var doc *schema.Session
+
+	err := s.exec(func() error {
+		col := s.collection(s.name)
+
+		doc = &schema.Session{}
+		_doc, err := col.Get(id, doc)
+		if err != nil || _doc == nil {
+			doc = nil
+			return err
+		}
+
+		needUpdate := false
+
+		if schemaUpdate.State != nil {
+			needUpdate = true
+			doc.State = *schemaUpdate.State
+		}
+
+		if schemaUpdate.Errors != nil {
+			needUpdate = true
+			doc.Errors = *schemaUpdate.Errors
+		}
+
+		if schemaUpdate.EndAt != nil {
+			needUpdate = true
+			doc.EndAt = schemaUpdate.EndAt
+		}
+
+		if schemaUpdate.UpdatedAt != nil {
+			needUpdate = true
+			doc.UpdatedAt = schemaUpdate.UpdatedAt
+		}
+
+		if schemaUpdate.Timeout != nil {
+			needUpdate = true
+			doc.Timeout = *schemaUpdate.Timeout
+		}
+
+		if schemaUpdate.Retries != nil {
+			needUpdate = true
+			doc.Retries = *schemaUpdate.Retries
+		}
+
+		if schemaUpdate.TerminateReason != nil {
+			needUpdate = true
+			doc.TerminateReason = *schemaUpdate.TerminateReason
+		}
+
+		if schemaUpdate.NextRetryAt != nil {
+			needUpdate = true
+			doc.NextRetryAt = schemaUpdate.NextRetryAt
+		}
+
+		if schemaUpdate.Decision != nil {
+			needUpdate = true
+			doc.Decision = *schemaUpdate.Decision
+		}
+
+		if schemaUpdate.Notifications != nil {
+			needUpdate = true
+			doc.Notifications = *schemaUpdate.Notifications
+		}
+
+		// no changes
+		if !needUpdate {
+			return nil
+		}
+
+		if schemaUpdate.UpdatedAt == nil {
+			now := time.Now()
+			doc.UpdatedAt = &now
+		}
+
+		return col.Put(id, doc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
 }
 
 func (s *sessionRepo) DeleteById(id string) (*schema.Session, error) {
-	return nil, nil
+	var doc *schema.Session
+
+	err := s.exec(func() error {
+		col := s.collection(s.name)
+
+		doc = &schema.Session{}
+		_doc, err := col.Get(id, doc)
+		if err != nil || _doc == nil {
+			doc = nil
+			return err
+		}
+
+		return col.Delete(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
 }
@@ -0,0 +1,21 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/barrydevp/transcoorditor/pkg/store/memory"
+	"github.com/barrydevp/transcoorditor/pkg/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Interface {
+		s, err := memory.NewStore()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(s.Close)
+
+		return s
+	})
+}
@@ -39,7 +39,14 @@ func (s *lockTableRepo) Save(lockEnt *schema.LockEntry) error {
 			}
 		}
 
-		update := bson.D{{"key", lockEnt.Key}, {"owner", lockEnt.Owner}, {"expiredAt", lockEnt.ExpiredAt}}
+		update := bson.D{
+			{"key", lockEnt.Key},
+			{"owner", lockEnt.Owner},
+			{"mode", lockEnt.Mode},
+			{"holders", lockEnt.Holders},
+			{"expiredAt", lockEnt.ExpiredAt},
+			{"token", lockEnt.Token},
+		}
 		opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
 
 		doc = &schema.LockEntry{}
@@ -57,11 +64,22 @@ func (s *lockTableRepo) Save(lockEnt *schema.LockEntry) error {
 	return nil
 }
 
+// SaveMany saves entries one by one, standalone mongodb does not support transaction.
+// it is only atomic when running behind replset backend, which applies it on every node.
+func (s *lockTableRepo) SaveMany(lockEnts []*schema.LockEntry) error {
+	for _, lockEnt := range lockEnts {
+		if err := s.Save(lockEnt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *lockTableRepo) Update(lockEnt *schema.LockEntry) error {
 	_, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
 		doc := &schema.LockEntry{}
-		filter := bson.D{{"key", lockEnt.Key}, {"owner", lockEnt.Owner}}
-		err := s.col.FindOne(ctx, filter).Decode(doc)
+		err := s.col.FindOne(ctx, bson.D{{"key", lockEnt.Key}}).Decode(doc)
 
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
@@ -72,8 +90,14 @@ func (s *lockTableRepo) Update(lockEnt *schema.LockEntry) error {
 			}
 		}
 
+		if doc.Owner != lockEnt.Owner {
+			return nil, store.ErrLockNotOwner
+		}
+
+		filter := bson.D{{"key", lockEnt.Key}, {"owner", lockEnt.Owner}}
+
 		doc = &schema.LockEntry{}
-		update := bson.D{{"expiredAt", lockEnt.ExpiredAt}}
+		update := bson.D{{"expiredAt", lockEnt.ExpiredAt}, {"holders", lockEnt.Holders}}
 		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 		err = s.col.FindOneAndUpdate(ctx, filter, bson.D{{"$set", update}}, opts).Decode(doc)
@@ -122,7 +146,10 @@ func (s *lockTableRepo) Find(key string) (*schema.LockEntry, error) {
 func (s *lockTableRepo) FindWithOwner(key string, owner string) (*schema.LockEntry, error) {
 	doc, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
 		doc := &schema.LockEntry{}
-		filter := bson.D{{"key", key}, {"owner", owner}}
+		filter := bson.D{{"key", key}, {"$or", bson.A{
+			bson.D{{"owner", owner}},
+			bson.D{{"holders.owner", owner}},
+		}}}
 
 		err := s.col.FindOne(ctx, filter).Decode(doc)
 
@@ -146,6 +173,34 @@ func (s *lockTableRepo) FindWithOwner(key string, owner string) (*schema.LockEnt
 	return r, nil
 }
 
+func (s *lockTableRepo) FindAll() ([]*schema.LockEntry, error) {
+	var results []*schema.LockEntry
+
+	doc, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
+		filter := bson.D{}
+
+		cursor, err := s.col.Find(ctx, filter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	}, 30)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, _ := doc.([]*schema.LockEntry)
+
+	return r, nil
+}
+
 func (s *lockTableRepo) Delete(lockEnt *schema.LockEntry) error {
 	_, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
 		doc := &schema.LockEntry{}
@@ -171,27 +226,48 @@ func (s *lockTableRepo) Delete(lockEnt *schema.LockEntry) error {
 	return nil
 }
 
+// DeleteByOwner releases all locks held by owner, shared lock is only deleted when its last holder is released
 func (s *lockTableRepo) DeleteByOwner(owner string) (int64, error) {
 	doc, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
-		filter := bson.D{{"owner", owner}}
-
-		result, err := s.col.DeleteMany(ctx, filter)
+		filter := bson.D{{"$or", bson.A{
+			bson.D{{"owner", owner}},
+			bson.D{{"holders.owner", owner}},
+		}}}
 
+		var docs []*schema.LockEntry
+		cursor, err := s.col.Find(ctx, filter)
 		if err != nil {
 			return nil, err
 		}
+		if err = cursor.All(ctx, &docs); err != nil {
+			return nil, err
+		}
 
-		return result.DeletedCount, nil
+		count := int64(0)
+		for _, doc := range docs {
+			if !doc.ReleaseOwner(owner) {
+				continue
+			}
+
+			if doc.IsHeld() {
+				update := bson.D{{"expiredAt", doc.ExpiredAt}, {"holders", doc.Holders}}
+				_, err = s.col.UpdateOne(ctx, bson.D{{"key", doc.Key}}, bson.D{{"$set", update}})
+			} else {
+				_, err = s.col.DeleteOne(ctx, bson.D{{"key", doc.Key}})
+			}
+			if err != nil {
+				return nil, err
+			}
+			count++
+		}
+
+		return count, nil
 	}, 10)
 
 	if err != nil {
 		return 0, err
 	}
 
-	if err != nil {
-		return -1, err
-	}
-
 	r, _ := doc.(int64)
 
 	return r, nil
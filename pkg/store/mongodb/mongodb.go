@@ -94,7 +94,7 @@ func (s *mongodbBackend) Close() {
 	defer cancel()
 
 	if err := s.c.Disconnect(ctx); err != nil {
-		logger.Error("Oops... Cannot disconnect mongodb! Reason: %v", err)
+		logger.Errorf("Oops... Cannot disconnect mongodb! Reason: %v", err)
 
 		return
 	}
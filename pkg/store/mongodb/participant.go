@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
 	"github.com/barrydevp/transcoorditor/pkg/util"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -34,10 +35,25 @@ func normalizeParticipant(p *schema.Participant) {
 	if p.CompleteAction != nil {
 		p.CompleteAction.Data = TryConvertBsonDToM(p.CompleteAction.Data)
 	}
+
+	if p.PrepareAction != nil {
+		p.PrepareAction.Data = TryConvertBsonDToM(p.PrepareAction.Data)
+	}
 }
 
 func (s *participantRepo) Save(part *schema.Participant) error {
 	if _, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
+		filter := bson.D{{"id", part.SessionId}}
+		count, err := s.Db.Collection("sessions").CountDocuments(ctx, filter, options.Count().SetLimit(1))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if count == 0 {
+			return nil, store.ErrSessionNotFound
+		}
+
 		inserted, err := s.col.InsertOne(ctx, part)
 
 		if err != nil {
@@ -79,6 +95,10 @@ func (s *participantRepo) PutBySessionAndId(sessionId string, id int64, partUpda
 		update = append(update, bson.E{"completeAction", partUpdate.CompleteAction})
 	}
 
+	if partUpdate.PrepareAction != nil {
+		update = append(update, bson.E{"prepareAction", partUpdate.PrepareAction})
+	}
+
 	// no changes
 	if len(update) == 0 {
 		return s.FindBySessionAndId(sessionId, id)
@@ -237,6 +257,18 @@ func (s *participantRepo) UpdateBySessionAndId(sessionId string, id int64, partU
 		update = append(update, bson.E{"completeAction", partUpdate.CompleteAction})
 	}
 
+	if partUpdate.PrepareAction != nil {
+		update = append(update, bson.E{"prepareAction", partUpdate.PrepareAction})
+	}
+
+	if partUpdate.LeaseExpiredAt != nil {
+		update = append(update, bson.E{"leaseExpiredAt", partUpdate.LeaseExpiredAt})
+	}
+
+	if partUpdate.ReservationExpiredAt != nil {
+		update = append(update, bson.E{"reservationExpiredAt", partUpdate.ReservationExpiredAt})
+	}
+
 	// no changes
 	if len(update) == 0 {
 		return s.FindBySessionAndId(sessionId, id)
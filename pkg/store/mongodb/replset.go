@@ -2,6 +2,8 @@ package mongodb
 
 import (
 	"context"
+
+	"github.com/barrydevp/transcoorditor/pkg/store"
 	"github.com/barrydevp/transcoorditor/pkg/util"
 	"github.com/hashicorp/raft"
 
@@ -61,7 +63,7 @@ func (s *replsetRepo) GetLastLog() (*raft.Log, error) {
 			return nil, err
 		}
 		if len(results) == 0 {
-			return nil, mongo.ErrNoDocuments
+			return nil, nil
 		}
 
 		return results[0], nil
@@ -75,3 +77,63 @@ func (s *replsetRepo) GetLastLog() (*raft.Log, error) {
 
 	return r, nil
 }
+
+func insertAll(ctx context.Context, col *mongo.Collection, n int, doc func(i int) interface{}) error {
+	if n == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, n)
+	for i := range docs {
+		docs[i] = doc(i)
+	}
+
+	_, err := col.InsertMany(ctx, docs)
+
+	return err
+}
+
+// Restore replaces every collection by the given state.
+// Standalone mongodb does not support multi-document transaction, so a failure in the middle leaves
+// the store partially restored, the replset will retry on the next snapshot install
+func (s *replsetRepo) Restore(state *store.State) error {
+	_, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
+		sessions := s.Db.Collection("sessions")
+		participants := s.Db.Collection("participants")
+		locks := s.Db.Collection("locktable")
+
+		for _, col := range []*mongo.Collection{sessions, participants, locks, s.col} {
+			if _, err := col.DeleteMany(ctx, bson.D{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := insertAll(ctx, sessions, len(state.Sessions), func(i int) interface{} {
+			return state.Sessions[i]
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := insertAll(ctx, participants, len(state.Participants), func(i int) interface{} {
+			return state.Participants[i]
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := insertAll(ctx, locks, len(state.Locks), func(i int) interface{} {
+			return state.Locks[i]
+		}); err != nil {
+			return nil, err
+		}
+
+		if state.LastLog != nil {
+			if _, err := s.col.InsertOne(ctx, state.LastLog); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	}, 60)
+
+	return err
+}
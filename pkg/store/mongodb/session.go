@@ -28,13 +28,16 @@ func NewSession(opts *baseRepo) *sessionRepo {
 
 func (s *sessionRepo) Save(session *schema.Session) error {
 	if _, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
-		inserted, err := s.col.InsertOne(ctx, session)
+		filter := bson.D{{"id", session.Id}}
+		opts := options.Replace().SetUpsert(true)
+
+		replaced, err := s.col.ReplaceOne(ctx, filter, session, opts)
 
 		if err != nil {
 			return nil, err
 		}
 
-		return inserted, nil
+		return replaced, nil
 	}, 10); err != nil {
 		return err
 	}
@@ -194,6 +197,77 @@ func (s *sessionRepo) FindAllUnfinished() ([]*schema.Session, error) {
 	return r, nil
 }
 
+func (s *sessionRepo) FindAllInProcessing() ([]*schema.Session, error) {
+	var results []*schema.Session
+
+	doc, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
+		filter := bson.D{{
+			"state", bson.D{{
+				"$in", schema.InProcessingSessionStates(),
+			}},
+		}}
+
+		cursor, err := s.col.Find(ctx, filter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	}, 30)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, _ := doc.([]*schema.Session)
+
+	return r, nil
+}
+
+func (s *sessionRepo) FindAllNotifying() ([]*schema.Session, error) {
+	var results []*schema.Session
+
+	doc, err := util.WithTimeout(func(ctx context.Context) (interface{}, error) {
+		// pending notifications are not queryable, sessions which have any are filtered below
+		filter := bson.D{{
+			"notifications.0", bson.D{{
+				"$exists", true,
+			}},
+		}}
+
+		cursor, err := s.col.Find(ctx, filter)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	}, 30)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var r []*schema.Session
+	sessions, _ := doc.([]*schema.Session)
+	for _, session := range sessions {
+		if session.IsNotifying() {
+			r = append(r, session)
+		}
+	}
+
+	return r, nil
+}
+
 func (s *sessionRepo) UpdateById(id string, schemaUpdate *schema.SessionUpdate) (*schema.Session, error) {
 	update := bson.D{}
 
@@ -225,6 +299,18 @@ func (s *sessionRepo) UpdateById(id string, schemaUpdate *schema.SessionUpdate)
 		update = append(update, bson.E{"terminateReason", schemaUpdate.TerminateReason})
 	}
 
+	if schemaUpdate.NextRetryAt != nil {
+		update = append(update, bson.E{"nextRetryAt", schemaUpdate.NextRetryAt})
+	}
+
+	if schemaUpdate.Decision != nil {
+		update = append(update, bson.E{"decision", schemaUpdate.Decision})
+	}
+
+	if schemaUpdate.Notifications != nil {
+		update = append(update, bson.E{"notifications", schemaUpdate.Notifications})
+	}
+
 	// no changes
 	if len(update) == 0 {
 		return s.FindById(id)
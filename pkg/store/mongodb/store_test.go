@@ -0,0 +1,60 @@
+package mongodb_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/barrydevp/transcoorditor/pkg/store/mongodb"
+	"github.com/barrydevp/transcoorditor/pkg/store/storetest"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TEST_MONGODB_URI enables the conformance suite against a live mongodb,
+// each test case runs in its own database which is dropped afterward
+func TestStore(t *testing.T) {
+	uri := os.Getenv("TEST_MONGODB_URI")
+	if uri == "" {
+		t.Skip("TEST_MONGODB_URI is not set")
+	}
+
+	seq := 0
+	storetest.Run(t, func(t *testing.T) store.Interface {
+		seq++
+		db := fmt.Sprintf("transcoorditor_test_%v_%v", time.Now().UnixNano(), seq)
+		viper.Set("MONGODB_URI", uri)
+		viper.Set("MONGODB_DB", db)
+
+		s, err := mongodb.NewStore()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			dropDatabase(t, uri, db)
+			s.Close()
+		})
+
+		return s
+	})
+}
+
+func dropDatabase(t *testing.T, uri string, db string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Disconnect(ctx)
+
+	if err := client.Database(db).Drop(ctx); err != nil {
+		t.Error(err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/barrydevp/transcoorditor/pkg/cluster"
 	"github.com/barrydevp/transcoorditor/pkg/common"
 	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/barrydevp/transcoorditor/pkg/watch"
 	"github.com/hashicorp/raft"
 	"github.com/sirupsen/logrus"
 )
@@ -37,12 +38,15 @@ type replsetBackend struct {
 	// indicate that the store is in replaying cmd state which is happend when starting replset server (early period after you run server in replset mode)
 	replaying bool
 	lastLog   *raft.Log
+	// changes are published when they are applied, so every node can serve watches
+	broker *watch.Broker
 }
 
 func NewReplStore(s store.Interface, c *cluster.Cluster) (*replsetBackend, error) {
 	rs := &replsetBackend{
-		s: s,
-		c: c,
+		s:      s,
+		c:      c,
+		broker: watch.NewBroker(),
 	}
 
 	rs.internalSession = NewSession(rs)
@@ -78,14 +82,14 @@ func NewApplyErr(err error) *cluster.ApplyResponse {
 	}
 }
 
-func (s *replsetBackend) executeRPC(c *cluster.Command) *cluster.ApplyResponse {
+func (s *replsetBackend) executeRPC(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	switch c.Ns {
 	case "Session":
-		return s.internalSession.executeRPC(c)
+		return s.internalSession.executeRPC(c, log)
 	case "Participant":
-		return s.internalParticipant.executeRPC(c)
+		return s.internalParticipant.executeRPC(c, log)
 	case "LockTable":
-		return s.internalLockTable.executeRPC(c)
+		return s.internalLockTable.executeRPC(c, log)
 	}
 
 	return NewApplyErr(ErrNamespaceUnsupported)
@@ -109,7 +113,7 @@ func (rs *replsetBackend) Apply(c *cluster.Command, log *raft.Log) *cluster.Appl
 
 	switch c.Op {
 	case cluster.RpcOp:
-		resp = rs.executeRPC(c)
+		resp = rs.executeRPC(c, log)
 	default:
 		resp = NewApplyErr(ErrCmdUnsupported)
 	}
@@ -4,6 +4,7 @@ import (
 	"github.com/barrydevp/transcoorditor/pkg/cluster"
 	"github.com/barrydevp/transcoorditor/pkg/schema"
 	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/hashicorp/raft"
 )
 
 type lockTableRepo struct {
@@ -20,12 +21,14 @@ func NewLockTable(b *replsetBackend) *lockTableRepo {
 	}
 }
 
-func (s *lockTableRepo) executeRPC(c *cluster.Command) *cluster.ApplyResponse {
+func (s *lockTableRepo) executeRPC(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	method := string(c.K)
 
 	switch method {
 	case "Save":
-		return s.applySave(c)
+		return s.applySave(c, log)
+	case "SaveMany":
+		return s.applySaveMany(c, log)
 	case "Update":
 		return s.applyUpdate(c)
 	case "Delete":
@@ -37,33 +40,85 @@ func (s *lockTableRepo) executeRPC(c *cluster.Command) *cluster.ApplyResponse {
 	return NewApplyErr(ErrRpcUnsupported)
 }
 
+// Save assigns the fencing token of saved lock entry
 func (s *lockTableRepo) Save(lockEnt *schema.LockEntry) error {
 	cmd, err := cluster.NewRpcCmd(s.namespace, "Save", lockEnt)
 	if err != nil {
 		return err
 	}
 
-	_, err = s.c.Execute(cmd, executeTimeout)
+	res, err := s.c.Execute(cmd, executeTimeout)
 	if err != nil {
 		return err
 	}
+	if token, ok := res.(uint64); ok {
+		lockEnt.Token = token
+		return nil
+	}
 
-	return nil
+	return ErrUnExpectedResponse
 }
 
-func (s *lockTableRepo) applySave(c *cluster.Command) *cluster.ApplyResponse {
+func (s *lockTableRepo) applySave(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	lockEnt := &schema.LockEntry{}
 	err := cluster.ParseRpcCmd(c, lockEnt)
 	if err != nil {
 		return NewApplyErr(err)
 	}
 
+	// raft log index is increased monotonically and identical on every node
+	lockEnt.Token = log.Index
+
 	err = s.s.Save(lockEnt)
 	if err != nil {
 		return NewApplyErr(err)
 	}
 
-	return &cluster.ApplyResponse{}
+	return &cluster.ApplyResponse{
+		Res: lockEnt.Token,
+	}
+}
+
+// SaveMany saves all entries in a single command, they share the same fencing token
+func (s *lockTableRepo) SaveMany(lockEnts []*schema.LockEntry) error {
+	cmd, err := cluster.NewRpcCmd(s.namespace, "SaveMany", lockEnts)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.c.Execute(cmd, executeTimeout)
+	if err != nil {
+		return err
+	}
+	if token, ok := res.(uint64); ok {
+		for _, lockEnt := range lockEnts {
+			lockEnt.Token = token
+		}
+		return nil
+	}
+
+	return ErrUnExpectedResponse
+}
+
+func (s *lockTableRepo) applySaveMany(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
+	var lockEnts []*schema.LockEntry
+	err := cluster.ParseRpcCmd(c, &lockEnts)
+	if err != nil {
+		return NewApplyErr(err)
+	}
+
+	for _, lockEnt := range lockEnts {
+		lockEnt.Token = log.Index
+	}
+
+	err = s.s.SaveMany(lockEnts)
+	if err != nil {
+		return NewApplyErr(err)
+	}
+
+	return &cluster.ApplyResponse{
+		Res: log.Index,
+	}
 }
 
 func (s *lockTableRepo) Update(lockEnt *schema.LockEntry) error {
@@ -103,6 +158,10 @@ func (s *lockTableRepo) FindWithOwner(key string, owner string) (*schema.LockEnt
 	return s.s.FindWithOwner(key, owner)
 }
 
+func (s *lockTableRepo) FindAll() ([]*schema.LockEntry, error) {
+	return s.s.FindAll()
+}
+
 func (s *lockTableRepo) Delete(lockEnt *schema.LockEntry) (err error) {
 	cmd, err := cluster.NewRpcCmd(s.namespace, "Delete", lockEnt)
 	if err != nil {
@@ -133,7 +192,7 @@ func (s *lockTableRepo) applyDelete(c *cluster.Command) *cluster.ApplyResponse {
 }
 
 func (s *lockTableRepo) DeleteByOwner(owner string) (int64, error) {
-	cmd, err := cluster.NewRpcCmd(s.namespace, "DeleteByOnwer", owner)
+	cmd, err := cluster.NewRpcCmd(s.namespace, "DeleteByOwner", owner)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,88 @@
+package replset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/cluster"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store/replset"
+	"github.com/hashicorp/raft"
+)
+
+func TestLockFencingToken(t *testing.T) {
+	s := newBoltStore(t, "lock.db")
+
+	rs, err := replset.NewReplStore(s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apply := func(index uint64, lockEnt *schema.LockEntry) *cluster.ApplyResponse {
+		cmd, err := cluster.NewRpcCmd("LockTable", "Save", lockEnt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return rs.Apply(cmd, &raft.Log{Index: index, Term: 1})
+	}
+
+	resp := apply(7, schema.NewLockEntry("key", "owner", time.Minute))
+	if resp.Err != nil {
+		t.Fatal(resp.Err)
+	}
+	if token, ok := resp.Res.(uint64); !ok || token != 7 {
+		t.Errorf("expected token 7, got %v", resp.Res)
+	}
+
+	// token given by client is ignored
+	lockEnt := schema.NewLockEntry("key", "another", time.Minute)
+	lockEnt.Token = 1000
+	if resp := apply(9, lockEnt); resp.Err != nil {
+		t.Fatal(resp.Err)
+	}
+
+	doc, err := s.LockTable().Find("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc == nil || doc.Owner != "another" || doc.Token != 9 {
+		t.Errorf("expected lock of another with token 9, got %+v", doc)
+	}
+}
+
+func TestLockSaveManyFencingToken(t *testing.T) {
+	s := newBoltStore(t, "lock.db")
+
+	rs, err := replset.NewReplStore(s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lockEnts := []*schema.LockEntry{
+		schema.NewLockEntry("a", "owner", time.Minute),
+		schema.NewLockEntry("b", "owner", time.Minute),
+	}
+	cmd, err := cluster.NewRpcCmd("LockTable", "SaveMany", lockEnts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := rs.Apply(cmd, &raft.Log{Index: 11, Term: 1})
+	if resp.Err != nil {
+		t.Fatal(resp.Err)
+	}
+	if token, ok := resp.Res.(uint64); !ok || token != 11 {
+		t.Errorf("expected token 11, got %v", resp.Res)
+	}
+
+	for _, key := range []string{"a", "b"} {
+		doc, err := s.LockTable().Find(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if doc == nil || doc.Token != 11 {
+			t.Errorf("expected lock %v with token 11, got %+v", key, doc)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/barrydevp/transcoorditor/pkg/cluster"
 	"github.com/barrydevp/transcoorditor/pkg/schema"
 	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/hashicorp/raft"
 )
 
 type participantRepo struct {
@@ -20,16 +21,16 @@ func NewParticipant(b *replsetBackend) *participantRepo {
 	}
 }
 
-func (s *participantRepo) executeRPC(c *cluster.Command) *cluster.ApplyResponse {
+func (s *participantRepo) executeRPC(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	method := string(c.K)
 
 	switch method {
 	case "Save":
-		return s.applySave(c)
+		return s.applySave(c, log)
 	case "PutBySessionAndId":
-		return s.applyPutBySessionAndId(c)
+		return s.applyPutBySessionAndId(c, log)
 	case "UpdateBySessionAndId":
-		return s.applyUpdateBySessionAndId(c)
+		return s.applyUpdateBySessionAndId(c, log)
 	case "DeleteBySessionId":
 		return s.applyDeleteBySessionId(c)
 	}
@@ -37,17 +38,36 @@ func (s *participantRepo) executeRPC(c *cluster.Command) *cluster.ApplyResponse
 	return NewApplyErr(ErrRpcUnsupported)
 }
 
-func (s *participantRepo) applySave(c *cluster.Command) *cluster.ApplyResponse {
+// findPrev returns the participant before it is changed by the applied command and whether the change should be published
+func (s *participantRepo) findPrev(sessionId string, id int64) (*schema.Participant, bool) {
+	if !s.watching() {
+		return nil, false
+	}
+
+	prev, err := s.s.FindBySessionAndId(sessionId, id)
+	if err != nil {
+		logger.Warnf("cannot find previous participant %v of session %v, its change is not published: %v", id, sessionId, err)
+		return nil, false
+	}
+
+	return prev, true
+}
+
+func (s *participantRepo) applySave(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	part := &schema.Participant{}
 	err := cluster.ParseRpcCmd(c, part)
 	if err != nil {
 		return NewApplyErr(err)
 	}
 
+	prev, watching := s.findPrev(part.SessionId, part.Id)
 	err = s.s.Save(part)
 	if err != nil {
 		return NewApplyErr(err)
 	}
+	if watching {
+		s.publishParticipant(log, prev, part)
+	}
 
 	return &cluster.ApplyResponse{}
 }
@@ -83,7 +103,7 @@ func (s *participantRepo) PutBySessionAndId(sessionId string, id int64, update *
 	return nil, ErrUnExpectedResponse
 }
 
-func (s *participantRepo) applyPutBySessionAndId(c *cluster.Command) *cluster.ApplyResponse {
+func (s *participantRepo) applyPutBySessionAndId(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	sessionId := ""
 	id := int64(0)
 	update := &schema.Participant{}
@@ -92,10 +112,14 @@ func (s *participantRepo) applyPutBySessionAndId(c *cluster.Command) *cluster.Ap
 		return NewApplyErr(err)
 	}
 
+	prev, watching := s.findPrev(sessionId, id)
 	doc, err := s.s.PutBySessionAndId(sessionId, id, update)
 	if err != nil {
 		return NewApplyErr(err)
 	}
+	if watching {
+		s.publishParticipant(log, prev, doc)
+	}
 
 	return &cluster.ApplyResponse{
 		Res: doc,
@@ -137,7 +161,7 @@ func (s *participantRepo) UpdateBySessionAndId(sessionId string, id int64, updat
 	return nil, ErrUnExpectedResponse
 }
 
-func (s *participantRepo) applyUpdateBySessionAndId(c *cluster.Command) *cluster.ApplyResponse {
+func (s *participantRepo) applyUpdateBySessionAndId(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	sessionId := ""
 	id := int64(0)
 	update := &schema.ParticipantUpdate{}
@@ -146,10 +170,14 @@ func (s *participantRepo) applyUpdateBySessionAndId(c *cluster.Command) *cluster
 		return NewApplyErr(err)
 	}
 
+	prev, watching := s.findPrev(sessionId, id)
 	doc, err := s.s.UpdateBySessionAndId(sessionId, id, update)
 	if err != nil {
 		return NewApplyErr(err)
 	}
+	if watching {
+		s.publishParticipant(log, prev, doc)
+	}
 
 	return &cluster.ApplyResponse{
 		Res: doc,
@@ -4,6 +4,7 @@ import (
 	"github.com/barrydevp/transcoorditor/pkg/cluster"
 	"github.com/barrydevp/transcoorditor/pkg/schema"
 	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/hashicorp/raft"
 )
 
 type sessionRepo struct {
@@ -20,18 +21,18 @@ func NewSession(b *replsetBackend) *sessionRepo {
 	}
 }
 
-func (s *sessionRepo) executeRPC(c *cluster.Command) *cluster.ApplyResponse {
+func (s *sessionRepo) executeRPC(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	method := string(c.K)
 
 	switch method {
 	case "Save":
-		return s.applySave(c)
+		return s.applySave(c, log)
 	case "PutById":
-		return s.applyPutById(c)
+		return s.applyPutById(c, log)
 	case "UpdateById":
-		return s.applyUpdateById(c)
+		return s.applyUpdateById(c, log)
 	case "DeleteById":
-		return s.applyDeleteById(c)
+		return s.applyDeleteById(c, log)
 	}
 
 	return NewApplyErr(ErrRpcUnsupported)
@@ -51,17 +52,36 @@ func (s *sessionRepo) Save(session *schema.Session) error {
 	return nil
 }
 
-func (s *sessionRepo) applySave(c *cluster.Command) *cluster.ApplyResponse {
+// findPrev returns the session before it is changed by the applied command and whether the change should be published
+func (s *sessionRepo) findPrev(id string) (*schema.Session, bool) {
+	if !s.watching() {
+		return nil, false
+	}
+
+	prev, err := s.s.FindById(id)
+	if err != nil {
+		logger.Warnf("cannot find previous session %v, its change is not published: %v", id, err)
+		return nil, false
+	}
+
+	return prev, true
+}
+
+func (s *sessionRepo) applySave(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	session := &schema.Session{}
 	err := cluster.ParseRpcCmd(c, session)
 	if err != nil {
 		return NewApplyErr(err)
 	}
 
+	prev, watching := s.findPrev(session.Id)
 	err = s.s.Save(session)
 	if err != nil {
 		return NewApplyErr(err)
 	}
+	if watching {
+		s.publishSession(log, prev, session)
+	}
 
 	return &cluster.ApplyResponse{}
 }
@@ -83,7 +103,7 @@ func (s *sessionRepo) PutById(id string, update *schema.Session) (*schema.Sessio
 	return nil, ErrUnExpectedResponse
 }
 
-func (s *sessionRepo) applyPutById(c *cluster.Command) *cluster.ApplyResponse {
+func (s *sessionRepo) applyPutById(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	id := ""
 	update := &schema.Session{}
 	err := cluster.ParseRpcCmd(c, &id, update)
@@ -91,10 +111,14 @@ func (s *sessionRepo) applyPutById(c *cluster.Command) *cluster.ApplyResponse {
 		return NewApplyErr(err)
 	}
 
+	prev, watching := s.findPrev(id)
 	doc, err := s.s.PutById(id, update)
 	if err != nil {
 		return NewApplyErr(err)
 	}
+	if watching {
+		s.publishSession(log, prev, doc)
+	}
 
 	return &cluster.ApplyResponse{
 		Res: doc,
@@ -109,6 +133,14 @@ func (s *sessionRepo) FindAllUnfinished() ([]*schema.Session, error) {
 	return s.s.FindAllUnfinished()
 }
 
+func (s *sessionRepo) FindAllInProcessing() ([]*schema.Session, error) {
+	return s.s.FindAllInProcessing()
+}
+
+func (s *sessionRepo) FindAllNotifying() ([]*schema.Session, error) {
+	return s.s.FindAllNotifying()
+}
+
 func (s *sessionRepo) FindById(id string) (session *schema.Session, err error) {
 	session, err = s.s.FindById(id)
 
@@ -132,7 +164,7 @@ func (s *sessionRepo) UpdateById(id string, update *schema.SessionUpdate) (sessi
 	return nil, ErrUnExpectedResponse
 }
 
-func (s *sessionRepo) applyUpdateById(c *cluster.Command) *cluster.ApplyResponse {
+func (s *sessionRepo) applyUpdateById(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	id := ""
 	update := &schema.SessionUpdate{}
 	err := cluster.ParseRpcCmd(c, &id, update)
@@ -140,10 +172,14 @@ func (s *sessionRepo) applyUpdateById(c *cluster.Command) *cluster.ApplyResponse
 		return NewApplyErr(err)
 	}
 
+	prev, watching := s.findPrev(id)
 	doc, err := s.s.UpdateById(id, update)
 	if err != nil {
 		return NewApplyErr(err)
 	}
+	if watching {
+		s.publishSession(log, prev, doc)
+	}
 
 	return &cluster.ApplyResponse{
 		Res: doc,
@@ -167,7 +203,7 @@ func (s *sessionRepo) DeleteById(id string) (session *schema.Session, err error)
 	return nil, ErrUnExpectedResponse
 }
 
-func (s *sessionRepo) applyDeleteById(c *cluster.Command) *cluster.ApplyResponse {
+func (s *sessionRepo) applyDeleteById(c *cluster.Command, log *raft.Log) *cluster.ApplyResponse {
 	id := ""
 	err := cluster.ParseRpcCmd(c, &id)
 	if err != nil {
@@ -178,6 +214,9 @@ func (s *sessionRepo) applyDeleteById(c *cluster.Command) *cluster.ApplyResponse
 	if err != nil {
 		return NewApplyErr(err)
 	}
+	if doc != nil {
+		s.publish(newWatchEvent(log, schema.EventSessionDeleted, "", doc.Id, doc))
+	}
 
 	return &cluster.ApplyResponse{
 		Res: doc,
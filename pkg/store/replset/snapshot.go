@@ -0,0 +1,158 @@
+package replset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/barrydevp/transcoorditor/pkg/cluster"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/hashicorp/raft"
+)
+
+var (
+	ErrMalformedSnapshot = errors.New("malformed snapshot")
+)
+
+type snapshotKind string
+
+const (
+	snapshotHeader      snapshotKind = "Header"
+	snapshotSession     snapshotKind = "Session"
+	snapshotParticipant snapshotKind = "Participant"
+	snapshotLock        snapshotKind = "LockEntry"
+)
+
+// the snapshot is a stream of json records, the first one is always the header
+type snapshotRecord struct {
+	Kind        snapshotKind        `json:"kind"`
+	LastLog     *raft.Log           `json:"lastLog,omitempty"`
+	Session     *schema.Session     `json:"session,omitempty"`
+	Participant *schema.Participant `json:"participant,omitempty"`
+	Lock        *schema.LockEntry   `json:"lock,omitempty"`
+}
+
+type replsetSnapshot struct {
+	state *store.State
+}
+
+func (snap *replsetSnapshot) Persist(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	if err := enc.Encode(&snapshotRecord{Kind: snapshotHeader, LastLog: snap.state.LastLog}); err != nil {
+		return err
+	}
+
+	for _, session := range snap.state.Sessions {
+		if err := enc.Encode(&snapshotRecord{Kind: snapshotSession, Session: session}); err != nil {
+			return err
+		}
+	}
+
+	for _, part := range snap.state.Participants {
+		if err := enc.Encode(&snapshotRecord{Kind: snapshotParticipant, Participant: part}); err != nil {
+			return err
+		}
+	}
+
+	for _, lockEnt := range snap.state.Locks {
+		if err := enc.Encode(&snapshotRecord{Kind: snapshotLock, Lock: lockEnt}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (snap *replsetSnapshot) Release() {
+	snap.state = nil
+}
+
+// @overide for Applier
+func (rs *replsetBackend) Snapshot() (cluster.ApplierSnapshot, error) {
+	state := &store.State{}
+
+	lastLog, err := rs.s.Replset().GetLastLog()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get last log: %w", err)
+	}
+	state.LastLog = lastLog
+
+	sessions, err := rs.s.Session().Find(&schema.SessionSearch{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		parts, err := rs.s.Participant().FindBySessionId(session.Id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get participants of session %v: %w", session.Id, err)
+		}
+		state.Participants = append(state.Participants, parts...)
+
+		// participants are stored as their own records
+		clone := *session
+		clone.Participants = nil
+		state.Sessions = append(state.Sessions, &clone)
+	}
+
+	if state.Locks, err = rs.s.LockTable().FindAll(); err != nil {
+		return nil, fmt.Errorf("cannot get lock entries: %w", err)
+	}
+
+	logger.Infof("Snapshot captured, sessions=%v participants=%v locks=%v", len(state.Sessions), len(state.Participants), len(state.Locks))
+
+	return &replsetSnapshot{
+		state: state,
+	}, nil
+}
+
+// @overide for Applier
+func (rs *replsetBackend) Restore(r io.Reader) error {
+	state := &store.State{}
+	dec := json.NewDecoder(r)
+
+	header := &snapshotRecord{}
+	if err := dec.Decode(header); err != nil {
+		return fmt.Errorf("%w: cannot read header: %v", ErrMalformedSnapshot, err)
+	}
+	if header.Kind != snapshotHeader {
+		return fmt.Errorf("%w: expected header, got %v", ErrMalformedSnapshot, header.Kind)
+	}
+	state.LastLog = header.LastLog
+
+	for {
+		record := &snapshotRecord{}
+		if err := dec.Decode(record); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("%w: %v", ErrMalformedSnapshot, err)
+		}
+
+		switch record.Kind {
+		case snapshotSession:
+			state.Sessions = append(state.Sessions, record.Session)
+		case snapshotParticipant:
+			state.Participants = append(state.Participants, record.Participant)
+		case snapshotLock:
+			state.Locks = append(state.Locks, record.Lock)
+		default:
+			return fmt.Errorf("%w: unknown record %v", ErrMalformedSnapshot, record.Kind)
+		}
+	}
+
+	if err := rs.s.Replset().Restore(state); err != nil {
+		return fmt.Errorf("cannot restore snapshot: %w", err)
+	}
+
+	// the local state is now exactly at the snapshot's last log, only newer logs must be applied
+	rs.lastLog = state.LastLog
+	rs.replaying = state.LastLog != nil
+
+	logger.Infof("Snapshot restored, sessions=%v participants=%v locks=%v", len(state.Sessions), len(state.Participants), len(state.Locks))
+
+	return nil
+}
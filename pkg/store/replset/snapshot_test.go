@@ -0,0 +1,116 @@
+package replset_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/barrydevp/transcoorditor/pkg/store/boltdb"
+	"github.com/barrydevp/transcoorditor/pkg/store/replset"
+	"github.com/hashicorp/raft"
+	"github.com/spf13/viper"
+)
+
+func newBoltStore(t *testing.T, name string) store.Interface {
+	viper.Set("BOLTDB_PATH", filepath.Join(t.TempDir(), name))
+
+	s, err := boltdb.NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	src := newBoltStore(t, "src.db")
+
+	session := schema.NewSession(schema.NewSessionOption())
+	session.State = schema.SessionActive
+	if err := src.Session().Save(session); err != nil {
+		t.Fatal(err)
+	}
+	for i := int64(1); i <= 3; i++ {
+		part := schema.NewParticipant()
+		part.Id = i
+		part.SessionId = session.Id
+		part.ClientId = "client"
+		if err := src.Participant().Save(part); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lockEnt := schema.NewLockEntry("key", session.Id, 0)
+	if err := src.LockTable().Save(lockEnt); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Replset().SaveLastLog(&raft.Log{Index: 42, Term: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	srcRs, err := replset.NewReplStore(src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := srcRs.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := snap.Persist(buf); err != nil {
+		t.Fatal(err)
+	}
+	snap.Release()
+
+	// the destination has its own stale data which must be discarded by restore
+	dst := newBoltStore(t, "dst.db")
+	stale := schema.NewSession(schema.NewSessionOption())
+	if err := dst.Session().Save(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	dstRs, err := replset.NewReplStore(dst, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dstRs.Restore(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc, err := dst.Session().FindById(stale.Id); err != nil || doc != nil {
+		t.Errorf("stale session must be discarded, got %v, err %v", doc, err)
+	}
+
+	doc, err := dst.Session().FindById(session.Id)
+	if err != nil || doc == nil {
+		t.Fatalf("session was not restored, err %v", err)
+	}
+	if doc.State != schema.SessionActive {
+		t.Errorf("expected state %v, got %v", schema.SessionActive, doc.State)
+	}
+
+	parts, err := dst.Participant().FindBySessionId(session.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 3 {
+		t.Fatalf("expected 3 participants, got %v", len(parts))
+	}
+	for i, part := range parts {
+		if part.Id != int64(i+1) {
+			t.Errorf("expected participant %v at position %v, got %v", i+1, i, part.Id)
+		}
+	}
+
+	if l, err := dst.LockTable().Find("key"); err != nil || l == nil || l.Owner != session.Id {
+		t.Errorf("lock entry was not restored, got %v, err %v", l, err)
+	}
+
+	lastLog, err := dst.Replset().GetLastLog()
+	if err != nil || lastLog == nil || lastLog.Index != 42 {
+		t.Errorf("last log was not restored, got %v, err %v", lastLog, err)
+	}
+}
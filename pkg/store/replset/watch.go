@@ -0,0 +1,52 @@
+package replset
+
+import (
+	"strconv"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/watch"
+	"github.com/hashicorp/raft"
+)
+
+// Broker returns the broker of the changes which are applied to this node
+func (rs *replsetBackend) Broker() *watch.Broker {
+	return rs.broker
+}
+
+// newWatchEvent returns the event of the applied log, its id is the log index, so it is the same on every node
+func newWatchEvent(log *raft.Log, eventType schema.EventType, key string, sessionId string, data interface{}) *schema.Event {
+	event := schema.NewEvent(eventType, key, sessionId, data)
+	if log != nil {
+		event.Id = strconv.FormatUint(log.Index, 10)
+	}
+
+	return event
+}
+
+// watching returns true if the applied changes should be published, it is false if there is no watcher
+// or the old logs are replaying, so the apply does not read the previous docs for nothing
+func (rs *replsetBackend) watching() bool {
+	return !rs.replaying && rs.broker.Len() > 0
+}
+
+func (rs *replsetBackend) publish(event *schema.Event) {
+	rs.broker.Publish(event)
+}
+
+// publishSession publishes the state transition of session, prev is nil for the new session
+func (rs *replsetBackend) publishSession(log *raft.Log, prev *schema.Session, doc *schema.Session) {
+	if doc == nil || (prev != nil && prev.State == doc.State) {
+		return
+	}
+
+	rs.publish(newWatchEvent(log, schema.EventSessionStateChanged, "", doc.Id, doc))
+}
+
+// publishParticipant publishes the state transition of participant, prev is nil for the new participant
+func (rs *replsetBackend) publishParticipant(log *raft.Log, prev *schema.Participant, doc *schema.Participant) {
+	if doc == nil || (prev != nil && prev.State == doc.State) {
+		return
+	}
+
+	rs.publish(newWatchEvent(log, schema.EventParticipantStateChanged, strconv.FormatInt(doc.Id, 10), doc.SessionId, doc))
+}
@@ -0,0 +1,74 @@
+package replset_test
+
+import (
+	"testing"
+
+	"github.com/barrydevp/transcoorditor/pkg/cluster"
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store/replset"
+	"github.com/hashicorp/raft"
+)
+
+func TestApplyPublishesTransitions(t *testing.T) {
+	s := newBoltStore(t, "watch.db")
+
+	rs, err := replset.NewReplStore(s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session := schema.NewSession(schema.NewSessionOption())
+	session.State = schema.SessionStarted
+	other := schema.NewSession(schema.NewSessionOption())
+
+	w := rs.Broker().Watch(session.Id)
+	defer w.Close()
+
+	index := uint64(0)
+	apply := func(ns string, method string, args ...interface{}) {
+		t.Helper()
+
+		cmd, err := cluster.NewRpcCmd(ns, method, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index++
+		if resp := rs.Apply(cmd, &raft.Log{Index: index, Term: 1}); resp.Err != nil {
+			t.Fatal(resp.Err)
+		}
+	}
+
+	active := schema.SessionActive
+	timeout := 120
+	apply("Session", "Save", session)
+	apply("Session", "Save", other)
+	part := schema.NewParticipant()
+	part.SessionId = session.Id
+	part.Id = 1
+	apply("Participant", "Save", part)
+	apply("Session", "UpdateById", session.Id, &schema.SessionUpdate{State: &active})
+	// not a transition
+	apply("Session", "UpdateById", session.Id, &schema.SessionUpdate{Timeout: &timeout})
+	apply("Session", "DeleteById", session.Id)
+
+	expected := []struct {
+		id        string
+		eventType schema.EventType
+	}{
+		{"1", schema.EventSessionStateChanged},
+		{"3", schema.EventParticipantStateChanged},
+		{"4", schema.EventSessionStateChanged},
+		{"6", schema.EventSessionDeleted},
+	}
+
+	if len(w.C) != len(expected) {
+		t.Fatalf("expected %v events, got %v", len(expected), len(w.C))
+	}
+	for _, e := range expected {
+		event := <-w.C
+		if event.Id != e.id || event.Type != e.eventType || event.SessionId != session.Id {
+			t.Errorf("expected event %v %v, got %v %v of %v", e.id, e.eventType, event.Id, event.Type, event.SessionId)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package store
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/barrydevp/transcoorditor/pkg/cluster"
 	"github.com/barrydevp/transcoorditor/pkg/exception"
@@ -30,6 +32,8 @@ type (
 	Replset interface {
 		SaveLastLog(log *raft.Log) error
 		GetLastLog() (*raft.Log, error)
+		// Restore replaces all sessions, participants, lock entries and the last log with the given state
+		Restore(state *State) error
 	}
 
 	Session interface {
@@ -38,6 +42,9 @@ type (
 		FindById(id string) (*schema.Session, error)
 		Find(search *schema.SessionSearch) ([]*schema.Session, error)
 		FindAllUnfinished() ([]*schema.Session, error)
+		FindAllInProcessing() ([]*schema.Session, error)
+		// FindAllNotifying returns sessions which have pending notifications, see Session.IsNotifying
+		FindAllNotifying() ([]*schema.Session, error)
 		UpdateById(id string, update *schema.SessionUpdate) (*schema.Session, error)
 		DeleteById(id string) (*schema.Session, error)
 	}
@@ -55,14 +62,51 @@ type (
 
 	LockTable interface {
 		Save(l *schema.LockEntry) error
+		// SaveMany saves all entries at once or none of them
+		SaveMany(ls []*schema.LockEntry) error
 		Update(l *schema.LockEntry) error
 		Find(key string) (*schema.LockEntry, error)
 		FindWithOwner(key string, owner string) (*schema.LockEntry, error)
+		FindAll() ([]*schema.LockEntry, error)
 		Delete(l *schema.LockEntry) error
 		DeleteByOwner(owner string) (int64, error)
 	}
 )
 
+// State is a full copy of the data held by a store, used for replset snapshot and restore
+type State struct {
+	Sessions     []*schema.Session
+	Participants []*schema.Participant
+	Locks        []*schema.LockEntry
+	LastLog      *raft.Log
+}
+
+// EmbeddedSessions returns copies of the sessions keyed by id, with their participants embedded in id order,
+// for the stores which keep participants in their session document
+func (st *State) EmbeddedSessions() (map[string]*schema.Session, error) {
+	sessions := make(map[string]*schema.Session, len(st.Sessions))
+	for _, session := range st.Sessions {
+		clone := *session
+		clone.Participants = nil
+		sessions[clone.Id] = &clone
+	}
+	for _, part := range st.Participants {
+		session, ok := sessions[part.SessionId]
+		if !ok {
+			return nil, fmt.Errorf("%w: participant %v/%v", ErrSessionNotFound, part.SessionId, part.Id)
+		}
+		session.Participants = append(session.Participants, part)
+	}
+	// participant is looked up by its position in session
+	for _, session := range sessions {
+		sort.Slice(session.Participants, func(i, j int) bool {
+			return session.Participants[i].Id < session.Participants[j].Id
+		})
+	}
+
+	return sessions, nil
+}
+
 type Backend struct {
 	SessionImpl     Session
 	ParticipantImpl Participant
@@ -0,0 +1,264 @@
+package storetest
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
+)
+
+func testLockTable(t *testing.T, factory Factory) {
+	t.Run("SaveAndFind", func(t *testing.T) {
+		s := factory(t)
+
+		lockEnt := schema.NewLockEntry("key", "owner", time.Minute)
+		lockEnt.Token = 42
+		must(t, s.LockTable().Save(lockEnt))
+
+		doc, err := s.LockTable().Find(lockEnt.Key)
+		must(t, err)
+		if doc == nil {
+			t.Fatal("saved lock was not found")
+		}
+		if doc.Key != lockEnt.Key || doc.Owner != lockEnt.Owner || doc.Token != lockEnt.Token ||
+			!sameTime(doc.ExpiredAt, lockEnt.ExpiredAt) {
+			t.Errorf("expected %+v, got %+v", lockEnt, doc)
+		}
+
+		doc, err = s.LockTable().Find("missing")
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no lock, got %+v", doc)
+		}
+	})
+
+	t.Run("SaveOverwrites", func(t *testing.T) {
+		s := factory(t)
+
+		must(t, s.LockTable().Save(schema.NewLockEntry("key", "owner", time.Minute)))
+		must(t, s.LockTable().Save(schema.NewLockEntry("key", "another", time.Minute)))
+
+		doc, err := s.LockTable().Find("key")
+		must(t, err)
+		if doc == nil || doc.Owner != "another" {
+			t.Errorf("expected lock of another, got %+v", doc)
+		}
+
+		docs, err := s.LockTable().FindAll()
+		must(t, err)
+		if len(docs) != 1 {
+			t.Errorf("expected 1 lock, got %v", len(docs))
+		}
+	})
+
+	t.Run("SaveMany", func(t *testing.T) {
+		s := factory(t)
+
+		must(t, s.LockTable().Save(schema.NewLockEntry("b", "another", time.Minute)))
+
+		var lockEnts []*schema.LockEntry
+		for _, key := range []string{"a", "b", "c"} {
+			lockEnt := schema.NewLockEntry(key, "owner", time.Minute)
+			lockEnt.Token = 42
+			lockEnts = append(lockEnts, lockEnt)
+		}
+		must(t, s.LockTable().SaveMany(lockEnts))
+
+		docs, err := s.LockTable().FindAll()
+		must(t, err)
+		if len(docs) != len(lockEnts) {
+			t.Errorf("expected %v locks, got %v", len(lockEnts), len(docs))
+		}
+		for _, doc := range docs {
+			if doc.Owner != "owner" || doc.Token != 42 {
+				t.Errorf("expected lock of owner with token 42, got %+v", doc)
+			}
+		}
+
+		must(t, s.LockTable().SaveMany(nil))
+	})
+
+	t.Run("FindWithOwner", func(t *testing.T) {
+		s := factory(t)
+
+		must(t, s.LockTable().Save(schema.NewLockEntry("key", "owner", time.Minute)))
+
+		doc, err := s.LockTable().FindWithOwner("key", "owner")
+		must(t, err)
+		if doc == nil || doc.Owner != "owner" {
+			t.Errorf("expected lock of owner, got %+v", doc)
+		}
+
+		doc, err = s.LockTable().FindWithOwner("key", "another")
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no lock of another, got %+v", doc)
+		}
+
+		doc, err = s.LockTable().FindWithOwner("missing", "owner")
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no lock, got %+v", doc)
+		}
+
+		// holder of shared lock is its owner
+		must(t, s.LockTable().Save(schema.NewSharedLockEntry("shared", "reader", time.Minute)))
+
+		doc, err = s.LockTable().FindWithOwner("shared", "reader")
+		must(t, err)
+		if doc == nil || !doc.HasOwner("reader") {
+			t.Errorf("expected shared lock of reader, got %+v", doc)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		s := factory(t)
+
+		lockEnt := schema.NewLockEntry("key", "owner", time.Minute)
+		lockEnt.Token = 42
+		must(t, s.LockTable().Save(lockEnt))
+
+		expiredAt := lockEnt.ExpiredAt.Add(time.Hour)
+		must(t, s.LockTable().Update(&schema.LockEntry{Key: "key", Owner: "owner", ExpiredAt: &expiredAt}))
+
+		doc, err := s.LockTable().Find("key")
+		must(t, err)
+		if doc == nil || !sameTime(doc.ExpiredAt, &expiredAt) {
+			t.Errorf("expected expiredAt %v, got %+v", expiredAt, doc)
+		}
+		// update only extends the lock, the fencing token is kept
+		if doc != nil && doc.Token != lockEnt.Token {
+			t.Errorf("expected token %v, got %v", lockEnt.Token, doc.Token)
+		}
+
+		err = s.LockTable().Update(&schema.LockEntry{Key: "missing", Owner: "owner", ExpiredAt: &expiredAt})
+		if !errors.Is(err, store.ErrLockNotFound) {
+			t.Errorf("expected %v, got %v", store.ErrLockNotFound, err)
+		}
+
+		err = s.LockTable().Update(&schema.LockEntry{Key: "key", Owner: "another", ExpiredAt: &expiredAt})
+		if !errors.Is(err, store.ErrLockNotOwner) {
+			t.Errorf("expected %v, got %v", store.ErrLockNotOwner, err)
+		}
+	})
+
+	t.Run("Shared", func(t *testing.T) {
+		s := factory(t)
+
+		lockEnt := schema.NewSharedLockEntry("key", "reader-1", time.Minute)
+		must(t, s.LockTable().Save(lockEnt))
+
+		lockEnt.AddHolder("reader-2", time.Hour)
+		must(t, s.LockTable().Update(lockEnt))
+
+		doc, err := s.LockTable().Find("key")
+		must(t, err)
+		if doc == nil || !doc.IsShared() {
+			t.Fatalf("expected shared lock, got %+v", doc)
+		}
+		if len(doc.Holders) != 2 || !doc.HasOwner("reader-1") || !doc.HasOwner("reader-2") {
+			t.Errorf("expected holders reader-1 and reader-2, got %+v", doc.Holders)
+		}
+		if !sameTime(doc.ExpiredAt, lockEnt.ExpiredAt) || !sameTime(doc.GetHolder("reader-2").ExpiredAt, doc.ExpiredAt) {
+			t.Errorf("expected expiredAt %v, got %v", lockEnt.ExpiredAt, doc.ExpiredAt)
+		}
+
+		lockEnt.RemoveHolder("reader-1")
+		must(t, s.LockTable().Update(lockEnt))
+
+		doc, err = s.LockTable().Find("key")
+		must(t, err)
+		if doc == nil || len(doc.Holders) != 1 || doc.HasOwner("reader-1") {
+			t.Errorf("expected only holder reader-2, got %+v", doc)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		s := factory(t)
+
+		must(t, s.LockTable().Save(schema.NewLockEntry("key", "owner", time.Minute)))
+
+		// deleting a lock of another owner is a no-op
+		must(t, s.LockTable().Delete(&schema.LockEntry{Key: "key", Owner: "another"}))
+		doc, err := s.LockTable().Find("key")
+		must(t, err)
+		if doc == nil {
+			t.Fatal("lock of owner must not be deleted by another")
+		}
+
+		must(t, s.LockTable().Delete(&schema.LockEntry{Key: "key", Owner: "owner"}))
+		doc, err = s.LockTable().Find("key")
+		must(t, err)
+		if doc != nil {
+			t.Errorf("lock was not deleted")
+		}
+
+		must(t, s.LockTable().Delete(&schema.LockEntry{Key: "missing", Owner: "owner"}))
+	})
+
+	t.Run("DeleteByOwner", func(t *testing.T) {
+		s := factory(t)
+
+		for _, key := range []string{"a", "b", "c"} {
+			must(t, s.LockTable().Save(schema.NewLockEntry(key, "owner", time.Minute)))
+		}
+		must(t, s.LockTable().Save(schema.NewLockEntry("d", "another", time.Minute)))
+
+		// shared lock is deleted with its last holder
+		must(t, s.LockTable().Save(schema.NewSharedLockEntry("e", "owner", time.Minute)))
+		shared := schema.NewSharedLockEntry("f", "owner", time.Minute)
+		shared.AddHolder("another", time.Minute)
+		must(t, s.LockTable().Save(shared))
+
+		deleted, err := s.LockTable().DeleteByOwner("owner")
+		must(t, err)
+		if deleted != 5 {
+			t.Errorf("expected 5 released locks, got %v", deleted)
+		}
+
+		docs, err := s.LockTable().FindAll()
+		must(t, err)
+		sort.Slice(docs, func(i, j int) bool { return docs[i].Key < docs[j].Key })
+		if len(docs) != 2 || docs[0].Key != "d" || docs[1].Key != "f" {
+			t.Fatalf("expected only locks of another remain, got %+v", docs)
+		}
+		if len(docs[1].Holders) != 1 || !docs[1].HasOwner("another") {
+			t.Errorf("expected shared lock held by another, got %+v", docs[1].Holders)
+		}
+
+		deleted, err = s.LockTable().DeleteByOwner("missing")
+		must(t, err)
+		if deleted != 0 {
+			t.Errorf("expected 0 deleted locks, got %v", deleted)
+		}
+	})
+
+	t.Run("FindAll", func(t *testing.T) {
+		s := factory(t)
+
+		docs, err := s.LockTable().FindAll()
+		must(t, err)
+		if len(docs) != 0 {
+			t.Errorf("expected no locks, got %v", len(docs))
+		}
+
+		keys := map[string]bool{"a": true, "b": true, "c": true}
+		for key := range keys {
+			must(t, s.LockTable().Save(schema.NewLockEntry(key, "owner", time.Minute)))
+		}
+
+		docs, err = s.LockTable().FindAll()
+		must(t, err)
+		if len(docs) != len(keys) {
+			t.Errorf("expected %v locks, got %v", len(keys), len(docs))
+		}
+		for _, doc := range docs {
+			if !keys[doc.Key] {
+				t.Errorf("unexpected lock %+v", doc)
+			}
+		}
+	})
+}
@@ -0,0 +1,402 @@
+package storetest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
+)
+
+func newParticipant(sessionId string, id int64) *schema.Participant {
+	part := schema.NewParticipant()
+	part.Id = id
+	part.SessionId = sessionId
+	part.ClientId = fmt.Sprintf("client-%v", id)
+	part.RequestId = fmt.Sprintf("request-%v", id)
+
+	return part
+}
+
+func saveParticipants(t *testing.T, s store.Interface, sessionId string, n int) []*schema.Participant {
+	t.Helper()
+
+	parts := make([]*schema.Participant, n)
+	for i := range parts {
+		parts[i] = newParticipant(sessionId, int64(i+1))
+		must(t, s.Participant().Save(parts[i]))
+	}
+
+	return parts
+}
+
+func newAction(uri string) *schema.ParticipantAction {
+	return &schema.ParticipantAction{
+		Uri:    &uri,
+		Status: schema.PartActionCreated,
+	}
+}
+
+func testParticipant(t *testing.T, factory Factory) {
+	t.Run("SaveAndFind", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		parts := saveParticipants(t, s, session.Id, 3)
+
+		for _, part := range parts {
+			doc, err := s.Participant().FindBySessionAndId(session.Id, part.Id)
+			must(t, err)
+			if doc == nil {
+				t.Fatalf("participant %v was not found", part.Id)
+			}
+			if doc.Id != part.Id || doc.SessionId != part.SessionId || doc.ClientId != part.ClientId ||
+				doc.RequestId != part.RequestId || doc.State != part.State {
+				t.Errorf("expected %+v, got %+v", part, doc)
+			}
+			if !sameTime(doc.CreatedAt, part.CreatedAt) {
+				t.Errorf("expected createdAt %v, got %v", part.CreatedAt, doc.CreatedAt)
+			}
+		}
+
+		docs, err := s.Participant().FindBySessionId(session.Id)
+		must(t, err)
+		if len(docs) != len(parts) {
+			t.Errorf("expected %v participants, got %v", len(parts), len(docs))
+		}
+	})
+
+	t.Run("SaveIntoMissingSession", func(t *testing.T) {
+		s := factory(t)
+
+		err := s.Participant().Save(newParticipant("missing", 1))
+		if !errors.Is(err, store.ErrSessionNotFound) {
+			t.Errorf("expected %v, got %v", store.ErrSessionNotFound, err)
+		}
+	})
+
+	t.Run("FindInMissingSession", func(t *testing.T) {
+		s := factory(t)
+
+		doc, err := s.Participant().FindBySessionAndId("missing", 1)
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no participant, got %+v", doc)
+		}
+
+		docs, err := s.Participant().FindBySessionId("missing")
+		must(t, err)
+		if len(docs) != 0 {
+			t.Errorf("expected no participants, got %v", len(docs))
+		}
+
+		count, err := s.Participant().CountBySessionId("missing")
+		must(t, err)
+		if count != 0 {
+			t.Errorf("expected count 0, got %v", count)
+		}
+	})
+
+	t.Run("FindByMissingId", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		saveParticipants(t, s, session.Id, 2)
+
+		for _, id := range []int64{0, -1, 3} {
+			doc, err := s.Participant().FindBySessionAndId(session.Id, id)
+			must(t, err)
+			if doc != nil {
+				t.Errorf("expected no participant with id %v, got %+v", id, doc)
+			}
+		}
+	})
+
+	t.Run("FindDupInSession", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		other := saveSession(t, s, schema.SessionStarted)
+		parts := saveParticipants(t, s, session.Id, 2)
+
+		// duplicated when both clientId and requestId are matched
+		dup, err := s.Participant().FindDupInSession(session.Id, &schema.Participant{
+			ClientId:  parts[1].ClientId,
+			RequestId: parts[1].RequestId,
+		})
+		must(t, err)
+		if dup == nil || dup.Id != parts[1].Id {
+			t.Errorf("expected duplicated participant %v, got %+v", parts[1].Id, dup)
+		}
+
+		dup, err = s.Participant().FindDupInSession(session.Id, &schema.Participant{
+			ClientId:  parts[0].ClientId,
+			RequestId: parts[1].RequestId,
+		})
+		must(t, err)
+		if dup != nil {
+			t.Errorf("expected no duplicated participant, got %+v", dup)
+		}
+
+		dup, err = s.Participant().FindDupInSession(other.Id, &schema.Participant{
+			ClientId:  parts[0].ClientId,
+			RequestId: parts[0].RequestId,
+		})
+		must(t, err)
+		if dup != nil {
+			t.Errorf("participants of another session must not be duplicated, got %+v", dup)
+		}
+
+		dup, err = s.Participant().FindDupInSession("missing", parts[0])
+		must(t, err)
+		if dup != nil {
+			t.Errorf("expected no duplicated participant, got %+v", dup)
+		}
+	})
+
+	t.Run("PutBySessionAndId", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		parts := saveParticipants(t, s, session.Id, 2)
+
+		doc, err := s.Participant().PutBySessionAndId(session.Id, parts[1].Id, &schema.Participant{
+			State:          schema.ParticipantCommitted,
+			CompleteAction: newAction("http://localhost/complete"),
+		})
+		must(t, err)
+		if doc == nil {
+			t.Fatal("put participant was not found")
+		}
+		if doc.State != schema.ParticipantCommitted || doc.CompleteAction == nil {
+			t.Errorf("put fields were not applied, got %+v", doc)
+		}
+		// empty fields are treated as absent fields
+		if doc.ClientId != parts[1].ClientId || doc.RequestId != parts[1].RequestId {
+			t.Errorf("empty fields must not overwrite, got %+v", doc)
+		}
+		if doc.UpdatedAt == nil {
+			t.Errorf("updatedAt must be set")
+		}
+
+		doc, err = s.Participant().FindBySessionAndId(session.Id, parts[1].Id)
+		must(t, err)
+		if doc.State != schema.ParticipantCommitted || doc.CompleteAction == nil || *doc.CompleteAction.Uri != "http://localhost/complete" {
+			t.Errorf("put was not persisted, got %+v", doc)
+		}
+
+		doc, err = s.Participant().FindBySessionAndId(session.Id, parts[0].Id)
+		must(t, err)
+		if doc.State != schema.ParticipantActive {
+			t.Errorf("other participant must not be changed, got %+v", doc)
+		}
+	})
+
+	t.Run("UpdateBySessionAndId", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		parts := saveParticipants(t, s, session.Id, 2)
+
+		state := schema.ParticipantCompensated
+		compensate := newAction("http://localhost/compensate")
+		compensate.Status = schema.PartActionCompleted
+		compensate.InvokedCount = 1
+		compensate.Method = "DELETE"
+		compensate.Headers = map[string]string{"Authorization": "Bearer token"}
+		compensate.TimeoutMs = 3000
+		compensate.SuccessStatusCodes = []int{200, 404}
+		doc, err := s.Participant().UpdateBySessionAndId(session.Id, parts[0].Id, &schema.ParticipantUpdate{
+			State:            &state,
+			CompensateAction: compensate,
+		})
+		must(t, err)
+		if doc == nil {
+			t.Fatal("updated participant was not found")
+		}
+
+		doc, err = s.Participant().FindBySessionAndId(session.Id, parts[0].Id)
+		must(t, err)
+		if doc.State != state {
+			t.Errorf("expected state %v, got %v", state, doc.State)
+		}
+		if doc.CompensateAction == nil || doc.CompensateAction.Status != schema.PartActionCompleted ||
+			doc.CompensateAction.InvokedCount != 1 {
+			t.Errorf("expected compensate action %+v, got %+v", compensate, doc.CompensateAction)
+		}
+		if action := doc.CompensateAction; action != nil && (action.Method != compensate.Method ||
+			action.Headers["Authorization"] != "Bearer token" || action.TimeoutMs != compensate.TimeoutMs ||
+			len(action.SuccessStatusCodes) != 2 || action.SuccessStatusCodes[1] != 404) {
+			t.Errorf("expected request options of %+v, got %+v", compensate, action)
+		}
+		if doc.UpdatedAt == nil {
+			t.Errorf("updatedAt must be set")
+		}
+
+		leaseExpiredAt := time.Now().Add(time.Minute)
+		prepare := newAction("http://localhost/prepare")
+		reservationExpiredAt := time.Now().Add(time.Hour)
+		_, err = s.Participant().UpdateBySessionAndId(session.Id, parts[1].Id, &schema.ParticipantUpdate{
+			LeaseExpiredAt:       &leaseExpiredAt,
+			PrepareAction:        prepare,
+			ReservationExpiredAt: &reservationExpiredAt,
+		})
+		must(t, err)
+
+		doc, err = s.Participant().FindBySessionAndId(session.Id, parts[1].Id)
+		must(t, err)
+		if !sameTime(doc.LeaseExpiredAt, &leaseExpiredAt) {
+			t.Errorf("expected leaseExpiredAt %v, got %v", leaseExpiredAt, doc.LeaseExpiredAt)
+		}
+		if doc.PrepareAction == nil || *doc.PrepareAction.Uri != *prepare.Uri {
+			t.Errorf("expected prepare action %+v, got %+v", prepare, doc.PrepareAction)
+		}
+		if !sameTime(doc.ReservationExpiredAt, &reservationExpiredAt) {
+			t.Errorf("expected reservationExpiredAt %v, got %v", reservationExpiredAt, doc.ReservationExpiredAt)
+		}
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		saveParticipants(t, s, session.Id, 1)
+
+		state := schema.ParticipantCommitted
+		for _, c := range []struct {
+			sessionId string
+			id        int64
+		}{{"missing", 1}, {session.Id, 2}} {
+			doc, err := s.Participant().UpdateBySessionAndId(c.sessionId, c.id, &schema.ParticipantUpdate{State: &state})
+			must(t, err)
+			if doc != nil {
+				t.Errorf("expected no updated participant, got %+v", doc)
+			}
+
+			doc, err = s.Participant().PutBySessionAndId(c.sessionId, c.id, &schema.Participant{State: state})
+			must(t, err)
+			if doc != nil {
+				t.Errorf("expected no put participant, got %+v", doc)
+			}
+		}
+	})
+
+	t.Run("CountAndDeleteBySessionId", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		other := saveSession(t, s, schema.SessionStarted)
+		saveParticipants(t, s, session.Id, 3)
+		saveParticipants(t, s, other.Id, 1)
+
+		count, err := s.Participant().CountBySessionId(session.Id)
+		must(t, err)
+		if count != 3 {
+			t.Errorf("expected count 3, got %v", count)
+		}
+
+		deleted, err := s.Participant().DeleteBySessionId(session.Id)
+		must(t, err)
+		if deleted != 3 {
+			t.Errorf("expected 3 deleted participants, got %v", deleted)
+		}
+
+		count, err = s.Participant().CountBySessionId(session.Id)
+		must(t, err)
+		if count != 0 {
+			t.Errorf("expected count 0, got %v", count)
+		}
+
+		count, err = s.Participant().CountBySessionId(other.Id)
+		must(t, err)
+		if count != 1 {
+			t.Errorf("participants of another session must not be deleted, got count %v", count)
+		}
+
+		deleted, err = s.Participant().DeleteBySessionId("missing")
+		must(t, err)
+		if deleted != 0 {
+			t.Errorf("expected 0 deleted participants, got %v", deleted)
+		}
+	})
+
+	t.Run("ConcurrentSave", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+
+		const n = 20
+		var wg sync.WaitGroup
+		errCh := make(chan error, n)
+		for i := 1; i <= n; i++ {
+			wg.Add(1)
+			go func(id int64) {
+				defer wg.Done()
+
+				if err := s.Participant().Save(newParticipant(session.Id, id)); err != nil {
+					errCh <- err
+				}
+			}(int64(i))
+		}
+		wg.Wait()
+		close(errCh)
+
+		for err := range errCh {
+			t.Error(err)
+		}
+
+		count, err := s.Participant().CountBySessionId(session.Id)
+		must(t, err)
+		if count != n {
+			t.Errorf("expected count %v, got %v", n, count)
+		}
+
+		// participants are found by id regardless of saving order
+		for i := int64(1); i <= n; i++ {
+			doc, err := s.Participant().FindBySessionAndId(session.Id, i)
+			must(t, err)
+			if doc == nil || doc.Id != i {
+				t.Errorf("expected participant %v, got %+v", i, doc)
+			}
+		}
+	})
+
+	t.Run("ConcurrentUpdate", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		parts := saveParticipants(t, s, session.Id, 10)
+
+		var wg sync.WaitGroup
+		errCh := make(chan error, len(parts))
+		for _, part := range parts {
+			wg.Add(1)
+			go func(id int64) {
+				defer wg.Done()
+
+				state := schema.ParticipantCommitted
+				if _, err := s.Participant().UpdateBySessionAndId(session.Id, id, &schema.ParticipantUpdate{State: &state}); err != nil {
+					errCh <- err
+				}
+			}(part.Id)
+		}
+		wg.Wait()
+		close(errCh)
+
+		for err := range errCh {
+			t.Error(err)
+		}
+
+		// updates of different participants in the same session must not be lost
+		docs, err := s.Participant().FindBySessionId(session.Id)
+		must(t, err)
+		for _, doc := range docs {
+			if doc.State != schema.ParticipantCommitted {
+				t.Errorf("update of participant %v was lost, got %v", doc.Id, doc.State)
+			}
+		}
+	})
+}
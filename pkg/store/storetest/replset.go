@@ -0,0 +1,112 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
+	"github.com/hashicorp/raft"
+)
+
+func testReplset(t *testing.T, factory Factory) {
+	t.Run("EmptyLastLog", func(t *testing.T) {
+		s := factory(t)
+
+		log, err := s.Replset().GetLastLog()
+		must(t, err)
+		if log != nil {
+			t.Errorf("expected no last log, got %+v", log)
+		}
+	})
+
+	t.Run("SaveLastLog", func(t *testing.T) {
+		s := factory(t)
+
+		for i := uint64(1); i <= 3; i++ {
+			must(t, s.Replset().SaveLastLog(&raft.Log{Index: i, Term: 1, Data: []byte("data")}))
+		}
+
+		log, err := s.Replset().GetLastLog()
+		must(t, err)
+		if log == nil || log.Index != 3 || log.Term != 1 || string(log.Data) != "data" {
+			t.Errorf("expected last log 3, got %+v", log)
+		}
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		s := factory(t)
+
+		// state before restoring must be discarded
+		stale := saveSession(t, s, schema.SessionStarted)
+		saveParticipants(t, s, stale.Id, 1)
+		must(t, s.LockTable().Save(schema.NewLockEntry("stale", "owner", time.Minute)))
+		must(t, s.Replset().SaveLastLog(&raft.Log{Index: 1, Term: 1}))
+
+		session := newSession(schema.SessionActive)
+		state := &store.State{
+			Sessions: []*schema.Session{session},
+			// out of order
+			Participants: []*schema.Participant{
+				newParticipant(session.Id, 2),
+				newParticipant(session.Id, 1),
+			},
+			Locks:   []*schema.LockEntry{schema.NewLockEntry("key", session.Id, time.Minute)},
+			LastLog: &raft.Log{Index: 10, Term: 2},
+		}
+		must(t, s.Replset().Restore(state))
+
+		doc, err := s.Session().FindById(stale.Id)
+		must(t, err)
+		if doc != nil {
+			t.Errorf("stale session must be discarded")
+		}
+
+		sessions, err := s.Session().Find(&schema.SessionSearch{})
+		must(t, err)
+		if len(sessions) != 1 || sessions[0].Id != session.Id || sessions[0].State != session.State {
+			t.Errorf("expected restored session %v, got %+v", session.Id, sessions)
+		}
+
+		for _, id := range []int64{1, 2} {
+			part, err := s.Participant().FindBySessionAndId(session.Id, id)
+			must(t, err)
+			if part == nil || part.Id != id {
+				t.Errorf("expected restored participant %v, got %+v", id, part)
+			}
+		}
+
+		locks, err := s.LockTable().FindAll()
+		must(t, err)
+		if len(locks) != 1 || locks[0].Key != "key" {
+			t.Errorf("expected restored lock, got %+v", locks)
+		}
+
+		log, err := s.Replset().GetLastLog()
+		must(t, err)
+		if log == nil || log.Index != 10 || log.Term != 2 {
+			t.Errorf("expected restored last log, got %+v", log)
+		}
+	})
+
+	t.Run("RestoreEmpty", func(t *testing.T) {
+		s := factory(t)
+
+		saveSession(t, s, schema.SessionStarted)
+		must(t, s.Replset().SaveLastLog(&raft.Log{Index: 1, Term: 1}))
+
+		must(t, s.Replset().Restore(&store.State{}))
+
+		sessions, err := s.Session().Find(&schema.SessionSearch{})
+		must(t, err)
+		if len(sessions) != 0 {
+			t.Errorf("expected no sessions, got %v", len(sessions))
+		}
+
+		log, err := s.Replset().GetLastLog()
+		must(t, err)
+		if log != nil {
+			t.Errorf("expected no last log, got %+v", log)
+		}
+	})
+}
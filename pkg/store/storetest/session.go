@@ -0,0 +1,397 @@
+package storetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/store"
+)
+
+func newSession(state schema.SessionState) *schema.Session {
+	session := schema.NewSession(schema.NewSessionOption())
+
+	now := time.Now()
+	session.State = state
+	session.StartedAt = &now
+
+	return session
+}
+
+func saveSession(t *testing.T, s store.Interface, state schema.SessionState) *schema.Session {
+	t.Helper()
+
+	session := newSession(state)
+	must(t, s.Session().Save(session))
+
+	return session
+}
+
+func sessionIds(sessions []*schema.Session) map[string]bool {
+	ids := make(map[string]bool, len(sessions))
+	for _, session := range sessions {
+		ids[session.Id] = true
+	}
+
+	return ids
+}
+
+func testSession(t *testing.T, factory Factory) {
+	t.Run("SaveAndFindById", func(t *testing.T) {
+		s := factory(t)
+
+		session := newSession(schema.SessionStarted)
+		lockKey := "lock-key"
+		session.LockKey = &lockKey
+		session.LockKeys = []string{"a", "b"}
+		session.RetryPolicy = &schema.RetryPolicy{InitialDelayMs: 10, Multiplier: 1.5, MaxDelayMs: 100, MaxAttempts: 3}
+		must(t, s.Session().Save(session))
+
+		doc, err := s.Session().FindById(session.Id)
+		must(t, err)
+		if doc == nil {
+			t.Fatal("saved session was not found")
+		}
+		if doc.Id != session.Id || doc.State != session.State || doc.Timeout != session.Timeout {
+			t.Errorf("expected %+v, got %+v", session, doc)
+		}
+		if doc.LockKey == nil || *doc.LockKey != lockKey {
+			t.Errorf("expected lock key %v, got %v", lockKey, doc.LockKey)
+		}
+		if len(doc.LockKeys) != 2 || doc.LockKeys[0] != "a" || doc.LockKeys[1] != "b" {
+			t.Errorf("expected lock keys %v, got %v", session.LockKeys, doc.LockKeys)
+		}
+		if doc.RetryPolicy == nil || *doc.RetryPolicy != *session.RetryPolicy {
+			t.Errorf("expected retry policy %+v, got %+v", session.RetryPolicy, doc.RetryPolicy)
+		}
+		if !sameTime(doc.StartedAt, session.StartedAt) {
+			t.Errorf("expected startedAt %v, got %v", session.StartedAt, doc.StartedAt)
+		}
+	})
+
+	t.Run("FindByMissingId", func(t *testing.T) {
+		s := factory(t)
+
+		doc, err := s.Session().FindById("missing")
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no session, got %+v", doc)
+		}
+	})
+
+	t.Run("SaveDuplicateOverwrites", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		session.State = schema.SessionActive
+		must(t, s.Session().Save(session))
+
+		docs, err := s.Session().Find(&schema.SessionSearch{})
+		must(t, err)
+		if len(docs) != 1 {
+			t.Fatalf("expected 1 session, got %v", len(docs))
+		}
+		if docs[0].State != schema.SessionActive {
+			t.Errorf("expected state %v, got %v", schema.SessionActive, docs[0].State)
+		}
+	})
+
+	t.Run("PutById", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		session.Retries = 3
+		must(t, s.Session().Save(session))
+
+		doc, err := s.Session().PutById(session.Id, &schema.Session{
+			State:   schema.SessionActive,
+			Timeout: 300,
+			Errors:  []string{"error"},
+		})
+		must(t, err)
+		if doc == nil {
+			t.Fatal("put session was not found")
+		}
+		if doc.State != schema.SessionActive || doc.Timeout != 300 || len(doc.Errors) != 1 {
+			t.Errorf("put fields were not applied, got %+v", doc)
+		}
+		// zero values are treated as absent fields
+		if doc.Retries != 3 {
+			t.Errorf("zero retries must not overwrite, expected 3, got %v", doc.Retries)
+		}
+		if doc.UpdatedAt == nil {
+			t.Errorf("updatedAt must be set")
+		}
+
+		doc, err = s.Session().FindById(session.Id)
+		must(t, err)
+		if doc.State != schema.SessionActive || doc.Retries != 3 {
+			t.Errorf("put was not persisted, got %+v", doc)
+		}
+	})
+
+	t.Run("PutByMissingId", func(t *testing.T) {
+		s := factory(t)
+
+		doc, err := s.Session().PutById("missing", &schema.Session{State: schema.SessionActive})
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no session, got %+v", doc)
+		}
+	})
+
+	t.Run("UpdateById", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+
+		state := schema.SessionCommitFailed
+		errs := []string{"failed"}
+		timeout := 60
+		retries := 0
+		reason := "reason"
+		endAt := time.Now()
+		nextRetryAt := endAt.Add(time.Minute)
+		decision := schema.DecisionAbort
+		notifications := []*schema.SessionNotification{{
+			Url:            "http://localhost/notify",
+			DeliveredState: schema.SessionStarted,
+			State:          state,
+			Attempts:       2,
+		}}
+		doc, err := s.Session().UpdateById(session.Id, &schema.SessionUpdate{
+			State:           &state,
+			Errors:          &errs,
+			Timeout:         &timeout,
+			Retries:         &retries,
+			TerminateReason: &reason,
+			EndAt:           &endAt,
+			NextRetryAt:     &nextRetryAt,
+			Decision:        &decision,
+			Notifications:   &notifications,
+		})
+		must(t, err)
+		if doc == nil {
+			t.Fatal("updated session was not found")
+		}
+
+		doc, err = s.Session().FindById(session.Id)
+		must(t, err)
+		if doc.State != state || doc.Timeout != timeout || doc.Retries != retries || doc.TerminateReason != reason ||
+			doc.Decision != decision {
+			t.Errorf("update was not persisted, got %+v", doc)
+		}
+		if len(doc.Errors) != 1 || doc.Errors[0] != errs[0] {
+			t.Errorf("expected errors %v, got %v", errs, doc.Errors)
+		}
+		if !sameTime(doc.EndAt, &endAt) {
+			t.Errorf("expected endAt %v, got %v", endAt, doc.EndAt)
+		}
+		if !sameTime(doc.NextRetryAt, &nextRetryAt) {
+			t.Errorf("expected nextRetryAt %v, got %v", nextRetryAt, doc.NextRetryAt)
+		}
+		if len(doc.Notifications) != 1 || *doc.Notifications[0] != *notifications[0] {
+			t.Errorf("expected notifications %+v, got %+v", notifications, doc.Notifications)
+		}
+		if doc.UpdatedAt == nil {
+			t.Errorf("updatedAt must be set")
+		}
+	})
+
+	t.Run("UpdateByMissingId", func(t *testing.T) {
+		s := factory(t)
+
+		state := schema.SessionActive
+		doc, err := s.Session().UpdateById("missing", &schema.SessionUpdate{State: &state})
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no session, got %+v", doc)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		s := factory(t)
+
+		saved := []*schema.Session{
+			saveSession(t, s, schema.SessionStarted),
+			saveSession(t, s, schema.SessionCommitted),
+			saveSession(t, s, schema.SessionAborted),
+		}
+
+		docs, err := s.Session().Find(&schema.SessionSearch{})
+		must(t, err)
+		ids := sessionIds(docs)
+		if len(docs) != len(saved) {
+			t.Errorf("expected %v sessions, got %v", len(saved), len(docs))
+		}
+		for _, session := range saved {
+			if !ids[session.Id] {
+				t.Errorf("session %v was not found", session.Id)
+			}
+		}
+	})
+
+	t.Run("FindAllUnfinished", func(t *testing.T) {
+		s := factory(t)
+
+		unfinished := map[string]bool{}
+		for _, state := range schema.UnfinishedSessionStates() {
+			unfinished[saveSession(t, s, schema.SessionState(state)).Id] = true
+		}
+		for _, state := range []schema.SessionState{schema.SessionCommitted, schema.SessionAborted, schema.SessionTerminated} {
+			saveSession(t, s, state)
+		}
+		for _, state := range schema.InProcessingSessionStates() {
+			saveSession(t, s, schema.SessionState(state))
+		}
+
+		docs, err := s.Session().FindAllUnfinished()
+		must(t, err)
+		ids := sessionIds(docs)
+		if len(ids) != len(unfinished) {
+			t.Errorf("expected %v unfinished sessions, got %v", len(unfinished), len(ids))
+		}
+		for id := range unfinished {
+			if !ids[id] {
+				t.Errorf("unfinished session %v was not found", id)
+			}
+		}
+	})
+
+	t.Run("FindAllInProcessing", func(t *testing.T) {
+		s := factory(t)
+
+		inProcessing := map[string]bool{}
+		for _, state := range schema.InProcessingSessionStates() {
+			inProcessing[saveSession(t, s, schema.SessionState(state)).Id] = true
+		}
+		for _, state := range schema.UnfinishedSessionStates() {
+			saveSession(t, s, schema.SessionState(state))
+		}
+		saveSession(t, s, schema.SessionCommitted)
+
+		docs, err := s.Session().FindAllInProcessing()
+		must(t, err)
+		ids := sessionIds(docs)
+		if len(ids) != len(inProcessing) {
+			t.Errorf("expected %v in-processing sessions, got %v", len(inProcessing), len(ids))
+		}
+		for id := range inProcessing {
+			if !ids[id] {
+				t.Errorf("in-processing session %v was not found", id)
+			}
+		}
+	})
+
+	t.Run("FindAllNotifying", func(t *testing.T) {
+		s := factory(t)
+
+		newNotifying := func(state schema.SessionState, deliveredState schema.SessionState) *schema.Session {
+			session := newSession(state)
+			session.Notifications = []*schema.SessionNotification{{
+				Url:            "http://localhost/notify",
+				DeliveredState: deliveredState,
+			}}
+			must(t, s.Session().Save(session))
+
+			return session
+		}
+
+		notifying := newNotifying(schema.SessionCommitted, "")
+		newNotifying(schema.SessionAborted, schema.SessionAborted)
+		// intermediate states are not notified by default
+		newNotifying(schema.SessionActive, "")
+		saveSession(t, s, schema.SessionCommitted)
+
+		docs, err := s.Session().FindAllNotifying()
+		must(t, err)
+		if len(docs) != 1 || docs[0].Id != notifying.Id {
+			t.Errorf("expected notifying session %v, got %v", notifying.Id, sessionIds(docs))
+		}
+	})
+
+	t.Run("DeleteById", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+		other := saveSession(t, s, schema.SessionStarted)
+
+		doc, err := s.Session().DeleteById(session.Id)
+		must(t, err)
+		if doc == nil || doc.Id != session.Id {
+			t.Errorf("expected deleted session %v, got %+v", session.Id, doc)
+		}
+
+		doc, err = s.Session().FindById(session.Id)
+		must(t, err)
+		if doc != nil {
+			t.Errorf("session was not deleted")
+		}
+
+		doc, err = s.Session().FindById(other.Id)
+		must(t, err)
+		if doc == nil {
+			t.Errorf("other session must not be deleted")
+		}
+
+		doc, err = s.Session().DeleteById("missing")
+		must(t, err)
+		if doc != nil {
+			t.Errorf("expected no deleted session, got %+v", doc)
+		}
+	})
+
+	t.Run("ConcurrentUpdateById", func(t *testing.T) {
+		s := factory(t)
+
+		session := saveSession(t, s, schema.SessionStarted)
+
+		const n = 20
+		var wg sync.WaitGroup
+		errCh := make(chan error, n)
+		for i := 1; i <= n; i++ {
+			wg.Add(1)
+			go func(retries int) {
+				defer wg.Done()
+
+				if _, err := s.Session().UpdateById(session.Id, &schema.SessionUpdate{Retries: &retries}); err != nil {
+					errCh <- err
+				}
+			}(i)
+		}
+		wg.Wait()
+		close(errCh)
+
+		for err := range errCh {
+			t.Error(err)
+		}
+
+		doc, err := s.Session().FindById(session.Id)
+		must(t, err)
+		if doc == nil || doc.Retries < 1 || doc.Retries > n {
+			t.Errorf("expected retries of one of the updates, got %+v", doc)
+		}
+		if doc != nil && doc.State != schema.SessionStarted {
+			t.Errorf("concurrent updates must not lose untouched fields, got %v", doc.State)
+		}
+	})
+
+	t.Run("ManySessions", func(t *testing.T) {
+		s := factory(t)
+
+		const n = 20
+		for i := 0; i < n; i++ {
+			session := newSession(schema.SessionStarted)
+			session.Id = fmt.Sprintf("session-%02d", i)
+			must(t, s.Session().Save(session))
+		}
+
+		docs, err := s.Session().Find(&schema.SessionSearch{})
+		must(t, err)
+		if len(docs) != n {
+			t.Errorf("expected %v sessions, got %v", n, len(docs))
+		}
+	})
+}
@@ -0,0 +1,62 @@
+// Package storetest is a conformance test suite for store.Interface backends.
+//
+// A backend wires the suite in its own test:
+//
+//	func TestStore(t *testing.T) {
+//		storetest.Run(t, func(t *testing.T) store.Interface {
+//			s, err := NewStore()
+//			...
+//			return s
+//		})
+//	}
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/barrydevp/transcoorditor/pkg/store"
+)
+
+// Factory returns a new empty store, it is called once for each test case
+type Factory func(t *testing.T) store.Interface
+
+// Run runs every test of the suite against stores created by the factory
+func Run(t *testing.T, factory Factory) {
+	t.Run("Session", func(t *testing.T) {
+		testSession(t, factory)
+	})
+	t.Run("Participant", func(t *testing.T) {
+		testParticipant(t, factory)
+	})
+	t.Run("LockTable", func(t *testing.T) {
+		testLockTable(t, factory)
+	})
+	t.Run("Replset", func(t *testing.T) {
+		testReplset(t, factory)
+	})
+}
+
+// some backends (eg: mongodb) store time in millisecond precision
+const timePrecision = time.Millisecond
+
+func sameTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	d := a.Sub(*b)
+	if d < 0 {
+		d = -d
+	}
+
+	return d < timePrecision
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,118 @@
+// Package watch fans out the changes applied to the local store to watchers, eg: server-sent events of sessions.
+package watch
+
+import (
+	"sync"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+)
+
+const defaultBufferSize = 256
+
+// Watcher receives events from Broker until it is closed.
+// C is closed when the watcher is closed or it is too slow to keep up with the events
+type Watcher struct {
+	C <-chan *schema.Event
+
+	b         *Broker
+	id        int
+	sessionId string
+	ch        chan *schema.Event
+}
+
+// Close unsubscribes the watcher, it is safe to call more than once
+func (w *Watcher) Close() {
+	w.b.remove(w.id)
+}
+
+// Broker publishes events to watchers without blocking, slow watchers are dropped instead of blocking the publisher
+type Broker struct {
+	mutex      sync.Mutex
+	nextId     int
+	watchers   map[int]*Watcher
+	bufferSize int
+	closed     bool
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		watchers:   make(map[int]*Watcher),
+		bufferSize: defaultBufferSize,
+	}
+}
+
+// Watch subscribes events of the given session, all events if sessionId is empty.
+// The watcher is closed already if the broker was closed
+func (b *Broker) Watch(sessionId string) *Watcher {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	ch := make(chan *schema.Event, b.bufferSize)
+	w := &Watcher{
+		C:         ch,
+		b:         b,
+		id:        b.nextId,
+		sessionId: sessionId,
+		ch:        ch,
+	}
+	b.nextId++
+
+	if b.closed {
+		close(ch)
+		return w
+	}
+	b.watchers[w.id] = w
+
+	return w
+}
+
+func (b *Broker) remove(id int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.unsafeRemove(id)
+}
+
+func (b *Broker) unsafeRemove(id int) {
+	if w, ok := b.watchers[id]; ok {
+		delete(b.watchers, id)
+		close(w.ch)
+	}
+}
+
+func (b *Broker) Publish(event *schema.Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for id, w := range b.watchers {
+		if w.sessionId != "" && w.sessionId != event.SessionId {
+			continue
+		}
+
+		select {
+		case w.ch <- event:
+		default:
+			// the watcher has missed an event, it must re-read the state and watch again
+			b.unsafeRemove(id)
+		}
+	}
+}
+
+// Close closes all watchers, eg: to end the streams before the server is shutdown
+func (b *Broker) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.closed = true
+	for id := range b.watchers {
+		b.unsafeRemove(id)
+	}
+}
+
+// Len returns the number of watchers
+func (b *Broker) Len() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return len(b.watchers)
+}
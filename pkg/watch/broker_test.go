@@ -0,0 +1,83 @@
+package watch_test
+
+import (
+	"testing"
+
+	"github.com/barrydevp/transcoorditor/pkg/schema"
+	"github.com/barrydevp/transcoorditor/pkg/watch"
+)
+
+func TestBrokerWatch(t *testing.T) {
+	b := watch.NewBroker()
+
+	all := b.Watch("")
+	defer all.Close()
+	one := b.Watch("a")
+
+	b.Publish(schema.NewEvent(schema.EventSessionStateChanged, "", "a", nil))
+	b.Publish(schema.NewEvent(schema.EventSessionStateChanged, "", "b", nil))
+
+	if len(all.C) != 2 {
+		t.Errorf("expected 2 events of all sessions, got %v", len(all.C))
+	}
+	if len(one.C) != 1 {
+		t.Fatalf("expected 1 event of session a, got %v", len(one.C))
+	}
+	if event := <-one.C; event.SessionId != "a" {
+		t.Errorf("expected event of session a, got %v", event.SessionId)
+	}
+
+	one.Close()
+	one.Close()
+	if _, ok := <-one.C; ok {
+		t.Errorf("expected closed watcher")
+	}
+	if b.Len() != 1 {
+		t.Errorf("expected 1 watcher, got %v", b.Len())
+	}
+}
+
+func TestBrokerDropSlowWatcher(t *testing.T) {
+	b := watch.NewBroker()
+
+	w := b.Watch("")
+	defer w.Close()
+
+	// publisher is never blocked by the watcher
+	for i := 0; i < 1000; i++ {
+		b.Publish(schema.NewEvent(schema.EventSessionStateChanged, "", "a", nil))
+	}
+
+	if b.Len() != 0 {
+		t.Errorf("expected slow watcher was dropped, got %v watchers", b.Len())
+	}
+
+	n := 0
+	for range w.C {
+		n++
+	}
+	if n == 0 || n >= 1000 {
+		t.Errorf("expected buffered events before drop, got %v", n)
+	}
+}
+
+func TestBrokerClose(t *testing.T) {
+	b := watch.NewBroker()
+
+	w := b.Watch("")
+	b.Close()
+
+	if _, ok := <-w.C; ok {
+		t.Errorf("expected watcher was closed")
+	}
+	if _, ok := <-b.Watch("a").C; ok {
+		t.Errorf("expected watcher of closed broker was closed")
+	}
+	if b.Len() != 0 {
+		t.Errorf("expected no watcher, got %v", b.Len())
+	}
+
+	// no-op
+	w.Close()
+	b.Publish(schema.NewEvent(schema.EventSessionStateChanged, "", "a", nil))
+}